@@ -10,89 +10,108 @@ import (
 	"context"
 	"github.com/kovansky/midas"
 	"github.com/kovansky/midas/concurrent"
+	"github.com/kovansky/midas/content"
 	"github.com/rs/zerolog"
 	"os/exec"
+	"path/filepath"
 )
 
 var _ midas.SiteService = (*SiteService)(nil)
 
-type SiteService struct {
-	Site midas.Site
+const (
+	// defaultRegistry is the registry of the sites without registry settings, tracking the content files.
+	defaultRegistry = "midas-registry.json"
+	// contentDir is the directory of the content collections, with a subdirectory for each collection.
+	contentDir = "src/content"
+	// dataDir is the default directory of the single types data files.
+	dataDir = "src/data"
+)
 
-	registry midas.RegistryService
+type SiteService struct {
+	content.Service
 }
 
 func NewSiteService(config midas.Site) (midas.SiteService, error) {
-	if _, ok := midas.RegistryServices[config.Registry.Type]; !ok {
-		return nil, midas.Errorf(midas.ErrSiteConfig, "requested registry type %s does not exit", config.Registry.Type)
-	}
-
-	siteService := SiteService{
-		Site:     config,
-		registry: midas.RegistryServices[config.Registry.Type](config),
+	if config.Registry.Type == "" {
+		config.Registry = midas.RegistrySettings{Type: "jsonfile", Location: defaultRegistry}
 	}
 
-	err := siteService.registry.OpenStorage()
+	service, err := content.NewService(config, Layout{})
 	if err != nil {
-		err = siteService.registry.CreateStorage()
-		if err != nil {
-			return nil, err
-		}
+		return nil, err
 	}
 
-	return siteService, nil
+	return SiteService{service}, nil
 }
 
-func (s SiteService) GetRegistryService() (midas.RegistryService, error) {
-	return s.registry, nil
+func (s SiteService) BuildSite(_ bool, _ zerolog.Logger) error {
+	return concurrent.Run(s.Site, "astro", func(ctx context.Context) *exec.Cmd {
+		cmd := exec.CommandContext(ctx, "astro", "build")
+		cmd.Dir = s.Site.RootDir
+
+		return cmd
+	})
 }
 
-func (s SiteService) BuildSite(_ bool, _ zerolog.Logger) error {
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+// Layout writes the entries of the collection types into the content collections (src/content/<model> by default), as
+// markdown, MDX or JSON data entries, and the single types as JSON data files (src/data/<model>.json by default).
+type Layout struct{}
 
-	cmd := exec.CommandContext(ctx, "astro", "build")
-	cmd.Dir = s.Site.RootDir
+func (l Layout) EntryPath(modelName string, model *midas.ModelSettings, payload midas.Payload) (string, string, string, error) {
+	var extension string
 
-	err := midas.Concurrents.Add(concurrent.New(s.Site, cancel))
-	if err != nil {
-		if midas.ErrorCode(err) != midas.ErrProcessNotFound {
-			return err
-		}
+	switch model.Output {
+	case "", midas.OutputMarkdown:
+		extension = ".md"
+	case midas.OutputMDX:
+		extension = ".mdx"
+	case midas.OutputJSON:
+		extension = ".json"
+	default:
+		return "", "", "", midas.Errorf(midas.ErrSiteConfig, "output %s of model %s is not supported", model.Output, modelName)
 	}
 
-	out, err := cmd.CombinedOutput()
+	if model.FrontMatter != "" && model.FrontMatter != midas.FrontMatterYAML {
+		return "", "", "", midas.Errorf(midas.ErrSiteConfig, "front matter format %s is not supported", model.FrontMatter)
+	}
 
-	select {
-	case <-ctx.Done():
-		switch ctx.Err() {
-		case context.Canceled:
-			return midas.Errorf(midas.ErrCancelled, "process cancelled")
-		}
-	default:
-		midas.Concurrents.Remove(s.Site.SiteName)
-		if err != nil {
-			return midas.Errorf(midas.ErrInternal, "astro build errored: %s\ncommand output: %s", err, out)
-		}
+	outputDir := model.OutputDir
+	if outputDir == "" {
+		outputDir = filepath.Join(contentDir, modelName)
 	}
-	return nil
-}
 
-// ToDo: implement.
-// As for now only build process is needed, the project it is used for gets the data directly from API.
+	filename, err := content.EntryFilename(modelName, model, payload)
 
-func (s SiteService) CreateEntry(_ midas.Payload) (string, error) {
-	return "", nil
+	return outputDir, filename, extension, err
 }
 
-func (s SiteService) UpdateEntry(_ midas.Payload) (string, error) {
-	return "", nil
+// RenderEntry renders the entry as markdown (or MDX), or as JSON data entry. The title, pubDate, updatedDate and draft
+// fields are added for the collection schemas, unless the entry has fields with the same names.
+func (l Layout) RenderEntry(model *midas.ModelSettings, payload midas.Payload) ([]byte, error) {
+	entry := payload.Entry()
+	predefined := map[string]interface{}{
+		"title":       content.Title(model, entry),
+		"pubDate":     content.Date(entry),
+		"updatedDate": entry["updatedAt"],
+		"draft":       payload.Metadata()["draft"],
+	}
+
+	if model.Output == midas.OutputJSON {
+		return content.RenderData(payload, predefined)
+	}
+
+	return content.RenderMarkdown(model, payload, predefined)
 }
 
-func (s SiteService) DeleteEntry(_ midas.Payload) (string, error) {
-	return "", nil
+func (l Layout) SinglePath(modelName string, model *midas.ModelSettings, _ string) string {
+	outputDir := model.OutputDir
+	if outputDir == "" {
+		outputDir = dataDir
+	}
+
+	return filepath.Join(outputDir, modelName+".json")
 }
 
-func (s SiteService) UpdateSingle(_ midas.Payload) (string, error) {
-	return "", nil
+func (l Layout) RenderSingle(_ *midas.ModelSettings, payload midas.Payload) ([]byte, error) {
+	return content.RenderJSON(payload)
 }
@@ -0,0 +1,121 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package astro
+
+import (
+	"github.com/kovansky/midas"
+	"github.com/kovansky/midas/bluemonday"
+	"github.com/kovansky/midas/jsonfile"
+	"github.com/kovansky/midas/mock"
+	"github.com/kovansky/midas/testing_utils"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLayout(t *testing.T) {
+	midas.Sanitizer = bluemonday.NewSanitizerService()
+
+	layout := Layout{}
+	payload := mock.NewPayload("post", map[string]interface{}{
+		"id": 1, "Title": "First", "Content": "Hello", "updatedAt": "2022-01-02T10:10:10.000Z",
+		"publishedAt": "2022-01-03T10:10:10.000Z",
+	}, "draft", false)
+
+	paths := map[string][]interface{}{
+		"Markdown":    {&midas.ModelSettings{}, filepath.Join("src", "content", "post", "first.md")},
+		"MDX":         {&midas.ModelSettings{OutputDir: "src/notes", Output: midas.OutputMDX}, filepath.Join("src", "notes", "first.mdx")},
+		"JSON":        {&midas.ModelSettings{Output: midas.OutputJSON}, filepath.Join("src", "content", "post", "first.json")},
+		"OutputDir":   {&midas.ModelSettings{OutputDir: "/srv/content"}, filepath.Join("/srv", "content", "first.md")},
+		"FrontMatter": {&midas.ModelSettings{FrontMatter: midas.FrontMatterYAML}, filepath.Join("src", "content", "post", "first.md")},
+	}
+
+	for name, test := range paths {
+		t.Run(name, func(t *testing.T) {
+			dir, filename, extension, err := layout.EntryPath("post", test[0].(*midas.ModelSettings), payload)
+
+			testing_utils.AssertTable(t, map[string][]interface{}{
+				"Error": {err, nil},
+				"Path":  {filepath.Join(dir, filename+extension), test[1]},
+			})
+		})
+	}
+
+	t.Run("RenderMarkdown", func(t *testing.T) {
+		rendered, err := layout.RenderEntry(&midas.ModelSettings{Output: midas.OutputMDX}, payload)
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Error": {err, nil},
+			"Content": {string(rendered), `---
+Title: First
+draft: false
+id: 1
+pubDate: 2022-01-03T10:10:10Z
+publishedAt: 2022-01-03T10:10:10Z
+title: First
+updatedAt: 2022-01-02T10:10:10Z
+updatedDate: 2022-01-02T10:10:10Z
+---
+
+Hello
+`},
+		})
+	})
+
+	t.Run("RenderJSON", func(t *testing.T) {
+		rendered, err := layout.RenderEntry(&midas.ModelSettings{Output: midas.OutputJSON}, payload)
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Error": {err, nil},
+			"Content": {string(rendered), `{
+  "Content": "Hello",
+  "Title": "First",
+  "draft": false,
+  "id": 1,
+  "pubDate": "2022-01-03T10:10:10.000Z",
+  "publishedAt": "2022-01-03T10:10:10.000Z",
+  "title": "First",
+  "updatedAt": "2022-01-02T10:10:10.000Z",
+  "updatedDate": "2022-01-02T10:10:10.000Z"
+}
+`},
+		})
+	})
+
+	t.Run("Single", func(t *testing.T) {
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Default":   {layout.SinglePath("homepage", &midas.ModelSettings{}, ""), filepath.Join("src", "data", "homepage.json")},
+			"OutputDir": {layout.SinglePath("homepage", &midas.ModelSettings{OutputDir: "src/globals"}, ""), filepath.Join("src", "globals", "homepage.json")},
+		})
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		_, _, _, outputErr := layout.EntryPath("post", &midas.ModelSettings{Output: midas.OutputHTML}, payload)
+		_, _, _, frontMatterErr := layout.EntryPath("post", &midas.ModelSettings{FrontMatter: midas.FrontMatterTOML}, payload)
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Output":      {midas.ErrorCode(outputErr), midas.ErrSiteConfig},
+			"FrontMatter": {midas.ErrorCode(frontMatterErr), midas.ErrSiteConfig},
+		})
+	})
+}
+
+func TestNewSiteService(t *testing.T) {
+	midas.RegistryServices = map[string]func(site midas.Site) midas.RegistryService{
+		"jsonfile": jsonfile.NewRegistryService,
+	}
+
+	root := t.TempDir()
+
+	if _, err := NewSiteService(midas.Site{RootDir: root}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := os.Stat(filepath.Join(root, defaultRegistry))
+
+	testing_utils.AssertEquals(t, err, nil, "Default registry")
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/kovansky/midas/walk"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -28,6 +29,9 @@ import (
 
 var _ midas.Deployment = (*Deployment)(nil)
 
+// invalidationPaths are the CloudFront paths invalidated after each deployment.
+var invalidationPaths = []string{"/*"}
+
 type Deployment struct {
 	site               midas.Site
 	deploymentSettings midas.DeploymentSettings
@@ -40,21 +44,7 @@ type Deployment struct {
 
 func New(site midas.Site, deploymentSettings midas.DeploymentSettings, isDraft bool) (midas.Deployment, error) {
 	// Get build destination directory
-	var publicPath = filepath.Join(site.RootDir, "public")
-
-	if !isDraft && site.OutputSettings.Build != "" {
-		if filepath.IsAbs(site.OutputSettings.Build) {
-			publicPath = site.OutputSettings.Build
-		} else {
-			publicPath = filepath.Join(site.RootDir, site.OutputSettings.Build)
-		}
-	} else if isDraft && site.OutputSettings.Draft != "" {
-		if filepath.IsAbs(site.OutputSettings.Draft) {
-			publicPath = site.OutputSettings.Draft
-		} else {
-			publicPath = filepath.Join(site.RootDir, site.OutputSettings.Draft)
-		}
-	}
+	var publicPath = site.PublicPath(isDraft)
 
 	cfg, err := config.LoadDefaultConfig(context.Background(),
 		config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(deploymentSettings.AWS.AccessKey, deploymentSettings.AWS.SecretKey, "")),
@@ -119,14 +109,78 @@ func (d *Deployment) Deploy() error {
 	return nil
 }
 
-// uploadFile uploads a file to the S3 bucket.
-func (d *Deployment) uploadFile(uploader *manager.Uploader, file *os.File, rel string) error {
+// Plan returns the changes Deploy would make in the AWS S3 bucket and CloudFront distribution, without changing
+// anything.
+//
+// As Deploy removes all objects from the bucket before the upload, objects which are uploaded again are reported as
+// updated, and the others as deleted.
+func (d *Deployment) Plan() (*midas.DeploymentPlan, error) {
+	walker, err := d.retrieveFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	// Drain the walker first, so the walking goroutine is not left blocked if listing fails.
+	localKeys := make(map[string]struct{})
+	for path := range walker {
+		rel, _ := filepath.Rel(d.publicPath, path)
+		localKeys[d.objectKey(rel)] = struct{}{}
+	}
+
+	currentObjects, err := d.listObjects()
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]struct{}, len(currentObjects))
+	for _, key := range currentObjects {
+		existing[key] = struct{}{}
+	}
+
+	plan := &midas.DeploymentPlan{
+		Upload: []string{},
+		Update: []string{},
+		Delete: []string{},
+	}
+
+	for key := range localKeys {
+		if _, ok := existing[key]; ok {
+			plan.Update = append(plan.Update, key)
+		} else {
+			plan.Upload = append(plan.Upload, key)
+		}
+	}
+
+	for key := range existing {
+		if _, ok := localKeys[key]; !ok {
+			plan.Delete = append(plan.Delete, key)
+		}
+	}
+
+	sort.Strings(plan.Upload)
+	sort.Strings(plan.Update)
+	sort.Strings(plan.Delete)
+
+	if d.deploymentSettings.AWS.CloudfrontDistribution != "" {
+		plan.Invalidate = invalidationPaths
+	}
+
+	return plan, nil
+}
+
+// objectKey returns the S3 object key of a file (relative to the public directory).
+func (d *Deployment) objectKey(rel string) string {
 	fileKey := rel
 	if d.deploymentSettings.AWS.S3Prefix != "" {
 		fileKey = fmt.Sprintf("%s/%s", d.deploymentSettings.AWS.S3Prefix, rel)
 	}
 
-	fileKey = strings.ReplaceAll(fileKey, "\\", "/")
+	return strings.ReplaceAll(fileKey, "\\", "/")
+}
+
+// uploadFile uploads a file to the S3 bucket.
+func (d *Deployment) uploadFile(uploader *manager.Uploader, file *os.File, rel string) error {
+	fileKey := d.objectKey(rel)
 
 	contentType := getFileContentType(file.Name())
 	cacheControl := getFileCacheControl(file.Name())
@@ -188,7 +242,7 @@ func (d *Deployment) deleteObjects(objects []string) error {
 
 // invalidateCloudfront invalidates the HTML files in the Cloudfront distribution.
 func (d *Deployment) invalidateCloudfront() error {
-	paths := []string{"/*"}
+	paths := invalidationPaths
 
 	if d.deploymentSettings.AWS.CloudfrontDistribution != "" {
 		_, err := d.cfClient.CreateInvalidation(context.Background(), &cloudfront.CreateInvalidationInput{
@@ -15,12 +15,23 @@ import (
 	"github.com/kovansky/midas/astro"
 	"github.com/kovansky/midas/aws"
 	"github.com/kovansky/midas/bluemonday"
+	"github.com/kovansky/midas/command"
 	"github.com/kovansky/midas/concurrent"
+	"github.com/kovansky/midas/contentful"
+	"github.com/kovansky/midas/directus"
+	"github.com/kovansky/midas/eleventy"
+	"github.com/kovansky/midas/ftp"
+	"github.com/kovansky/midas/generic"
 	"github.com/kovansky/midas/http"
 	"github.com/kovansky/midas/hugo"
+	"github.com/kovansky/midas/jekyll"
 	"github.com/kovansky/midas/jsonfile"
 	"github.com/kovansky/midas/none"
+	"github.com/kovansky/midas/payloadcms"
 	"github.com/kovansky/midas/sftp"
+	"github.com/kovansky/midas/strapi"
+	"github.com/kovansky/midas/webdav"
+	"github.com/kovansky/midas/zola"
 	"github.com/rollbar/rollbar-go"
 	"io/ioutil"
 	"log"
@@ -31,6 +42,13 @@ import (
 	"strings"
 )
 
+// commands are the subcommands of midasd, executed instead of starting the server.
+var commands = map[string]func(ctx context.Context, args []string) error{
+	"migrate": runMigrate,
+	"plan":    runPlan,
+	"sync":    runSync,
+}
+
 var (
 	commit, version, date, environment, logLevel string
 	logLevelAcceptedValues                       = map[string]struct{}{
@@ -55,6 +73,20 @@ func main() {
 	signal.Notify(c, os.Interrupt)
 	go func() { <-c; cancel() }()
 
+	// Execute the subcommand, if one was requested, instead of starting the server.
+	if len(os.Args) > 1 {
+		if command, ok := commands[os.Args[1]]; ok {
+			if err := command(ctx, os.Args[2:]); err == flag.ErrHelp {
+				os.Exit(1)
+			} else if err != nil {
+				_, _ = fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+
+			return
+		}
+	}
+
 	// Create a new type to represent the application.
 	m := NewMain()
 
@@ -153,21 +185,31 @@ func (m *Main) ParseFlags(_ context.Context, args []string) error {
 		logLevel = "info"
 	}
 
-	configPath, err := expand(m.ConfigPath)
+	config, err := loadConfig(m.ConfigPath)
 	if err != nil {
 		return err
 	}
 
+	m.Config = config
+
+	return nil
+}
+
+// loadConfig reads the config from given path, which may start with a tilde.
+func loadConfig(path string) (midas.Config, error) {
+	configPath, err := expand(path)
+	if err != nil {
+		return midas.Config{}, err
+	}
+
 	config, err := readConfig(configPath)
 	if os.IsNotExist(err) {
-		return fmt.Errorf("config file not found: %s", m.ConfigPath)
+		return config, fmt.Errorf("config file not found: %s", path)
 	} else if err != nil {
-		return err
+		return config, err
 	}
 
-	m.Config = config
-
-	return nil
+	return config, nil
 }
 
 // Run executes the program. The configuration should already be set up
@@ -189,15 +231,68 @@ func (m *Main) Run(_ context.Context) (err error) {
 
 	m.HTTPServer.Config = m.Config
 
-	m.HTTPServer.SiteServices = map[string]func(site midas.Site) (midas.SiteService, error){
+	m.HTTPServer.SiteServices = siteServices()
+	m.HTTPServer.Providers = providers()
+
+	registerRegistryServices()
+	registerDeploymentTargets()
+
+	midas.Sanitizer = bluemonday.NewSanitizerService()
+
+	midas.Concurrents = concurrent.NewList()
+
+	if err := m.HTTPServer.Open(); err != nil {
+		return err
+	}
+
+	if m.HTTPServer.UseTLS() {
+		go func() {
+			log.Fatal(http.ListenAndServeTLSRedirect(m.Config.Domain))
+		}()
+	}
+
+	log.Printf("Running on %s", m.HTTPServer.URL())
+
+	return nil
+}
+
+// siteServices returns the services of the static site generators, by the service name of the site.
+func siteServices() map[string]func(site midas.Site) (midas.SiteService, error) {
+	return map[string]func(site midas.Site) (midas.SiteService, error){
 		"hugo": func(site midas.Site) (midas.SiteService, error) {
 			return hugo.NewSiteService(site)
 		},
 		"astro": func(site midas.Site) (midas.SiteService, error) {
 			return astro.NewSiteService(site)
 		},
+		"eleventy": func(site midas.Site) (midas.SiteService, error) {
+			return eleventy.NewSiteService(site)
+		},
+		"jekyll": func(site midas.Site) (midas.SiteService, error) {
+			return jekyll.NewSiteService(site)
+		},
+		"zola": func(site midas.Site) (midas.SiteService, error) {
+			return zola.NewSiteService(site)
+		},
+		"command": func(site midas.Site) (midas.SiteService, error) {
+			return command.NewSiteService(site)
+		},
 	}
+}
+
+// providers returns the providers of the webhooks, by their name.
+func providers() map[string]midas.Provider {
+	return map[string]midas.Provider{
+		"strapi":     strapi.NewProvider(),
+		"contentful": contentful.NewProvider(),
+		"directus":   directus.NewProvider(),
+		"generic":    generic.NewProvider(),
+		"payloadcms": payloadcms.NewProvider(),
+	}
+}
 
+// registerRegistryServices sets up the registry storages available to the sites.
+func registerRegistryServices() {
 	midas.RegistryServices = map[string]func(site midas.Site) midas.RegistryService{
 		"jsonfile": func(site midas.Site) midas.RegistryService {
 			return jsonfile.NewRegistryService(site)
@@ -206,7 +301,10 @@ func (m *Main) Run(_ context.Context) (err error) {
 			return none.NewRegistryService(site)
 		},
 	}
+}
 
+// registerDeploymentTargets sets up the deployment targets available to the sites.
+func registerDeploymentTargets() {
 	midas.DeploymentTargets = map[string]func(site midas.Site, settings midas.DeploymentSettings, isDraft bool) (midas.Deployment, error){
 		"aws": func(site midas.Site, settings midas.DeploymentSettings, isDraft bool) (midas.Deployment, error) {
 			return aws.New(site, settings, isDraft)
@@ -214,25 +312,13 @@ func (m *Main) Run(_ context.Context) (err error) {
 		"sftp": func(site midas.Site, settings midas.DeploymentSettings, isDraft bool) (midas.Deployment, error) {
 			return sftp.New(site, settings, isDraft)
 		},
+		"webdav": func(site midas.Site, settings midas.DeploymentSettings, isDraft bool) (midas.Deployment, error) {
+			return webdav.New(site, settings, isDraft)
+		},
+		"ftp": func(site midas.Site, settings midas.DeploymentSettings, isDraft bool) (midas.Deployment, error) {
+			return ftp.New(site, settings, isDraft)
+		},
 	}
-
-	midas.Sanitizer = bluemonday.NewSanitizerService()
-
-	midas.Concurrents = concurrent.NewList()
-
-	if err := m.HTTPServer.Open(); err != nil {
-		return err
-	}
-
-	if m.HTTPServer.UseTLS() {
-		go func() {
-			log.Fatal(http.ListenAndServeTLSRedirect(m.Config.Domain))
-		}()
-	}
-
-	log.Printf("Running on %s", m.HTTPServer.URL())
-
-	return nil
 }
 
 // expand changes tilde in path to user's home directory.
@@ -0,0 +1,157 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/kovansky/midas"
+	"github.com/kovansky/midas/jsonfile"
+	"github.com/kovansky/midas/strapi"
+	"io"
+	"os"
+)
+
+// MigrateCommand renames the entries in the registry of a site from the Strapi v4 ids to the Strapi v5 document ids,
+// so the entries created before the upgrade are updated (instead of duplicated) by the Strapi v5 webhooks.
+type MigrateCommand struct {
+	Config     midas.Config
+	ConfigPath string
+	// Site is the API key or name of the site.
+	Site string
+
+	Out io.Writer
+}
+
+// runMigrate executes the migrate subcommand.
+func runMigrate(ctx context.Context, args []string) error {
+	c := &MigrateCommand{Out: os.Stdout}
+
+	if err := c.ParseFlags(ctx, args); err != nil {
+		return err
+	}
+
+	return c.Run(ctx)
+}
+
+// ParseFlags parses command line arguments and loads the config.
+func (c *MigrateCommand) ParseFlags(_ context.Context, args []string) error {
+	fs := flag.NewFlagSet("midasd migrate", flag.ContinueOnError)
+	fs.StringVar(&c.ConfigPath, "config", defaultConfigPath, "config path")
+	fs.StringVar(&c.Site, "site", "", "API key or name of the site to migrate")
+	fs.StringVar(&logLevel, "log", "info", "log level (trace, debug, info, warn, error, critical)")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if c.Site == "" {
+		return fmt.Errorf("site is required")
+	}
+
+	config, err := loadConfig(c.ConfigPath)
+	if err != nil {
+		return err
+	}
+
+	c.Config = config
+
+	return nil
+}
+
+// Run fetches the document ids of the entries of all models used by the site (including the related models) from the
+// Strapi v5 REST API, and renames the registry entries and dependencies.
+func (c *MigrateCommand) Run(_ context.Context) error {
+	site, err := findSite(c.Config, c.Site)
+	if err != nil {
+		return err
+	}
+
+	if site.Registry.Type != "jsonfile" {
+		return fmt.Errorf("migration is not supported by %s registry", site.Registry.Type)
+	}
+
+	log := commandLogger()
+
+	settings := site.Strapi
+	settings.Version = strapi.V5
+
+	client, err := strapi.NewClient(settings)
+	if err != nil {
+		return errors.New(midas.ErrorMessage(err))
+	}
+
+	ids := make(map[string]string)
+	addIds := func(modelName string, model midas.ModelSettings, isSingle bool) error {
+		documentIds, err := client.DocumentIds(modelName, model, isSingle)
+		if err != nil {
+			return errors.New(midas.ErrorMessage(err))
+		}
+
+		log.Debug().Msgf("Fetched %d document ids of %s", len(documentIds), modelName)
+
+		for id, documentId := range documentIds {
+			ids[id] = documentId
+		}
+
+		return nil
+	}
+
+	related := make(map[string]bool)
+	for modelName, model := range site.CollectionTypes {
+		if err = addIds(modelName, model, false); err != nil {
+			return err
+		}
+
+		for _, relatedModel := range model.Relations {
+			related[relatedModel] = true
+		}
+	}
+
+	for modelName, model := range site.SingleTypes {
+		if err = addIds(modelName, model, true); err != nil {
+			return err
+		}
+
+		for _, relatedModel := range model.Relations {
+			related[relatedModel] = true
+		}
+	}
+
+	for modelName := range related {
+		_, isCollection := site.CollectionTypes[modelName]
+		_, isSingle := site.SingleTypes[modelName]
+		if isCollection || isSingle {
+			continue
+		}
+
+		if err = addIds(modelName, midas.ModelSettings{}, false); err != nil {
+			return err
+		}
+	}
+
+	registry := jsonfile.NewRegistryService(site).(*jsonfile.RegistryService)
+	if err = registry.OpenStorage(); err != nil {
+		return err
+	}
+	defer registry.CloseStorage()
+
+	renamed, err := registry.RenameEntries(ids)
+	if err != nil {
+		return errors.New(midas.ErrorMessage(err))
+	}
+
+	if err = registry.Flush(); err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(c.Out, "Migrated %d entries\n", renamed)
+
+	return nil
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/kovansky/midas"
+	"github.com/kovansky/midas/deploy"
+	"github.com/rs/zerolog"
+	"io"
+	"os"
+)
+
+// PlanCommand prints the deployment plan (dry run) of a site, without building the site or changing anything on the
+// deployment targets.
+type PlanCommand struct {
+	Config     midas.Config
+	ConfigPath string
+	// Site is the API key or name of the site.
+	Site string
+
+	Out io.Writer
+}
+
+// runPlan executes the plan subcommand.
+func runPlan(ctx context.Context, args []string) error {
+	c := &PlanCommand{Out: os.Stdout}
+
+	if err := c.ParseFlags(ctx, args); err != nil {
+		return err
+	}
+
+	return c.Run(ctx)
+}
+
+// ParseFlags parses command line arguments and loads the config.
+func (c *PlanCommand) ParseFlags(_ context.Context, args []string) error {
+	fs := flag.NewFlagSet("midasd plan", flag.ContinueOnError)
+	fs.StringVar(&c.ConfigPath, "config", defaultConfigPath, "config path")
+	fs.StringVar(&c.Site, "site", "", "API key or name of the site to plan")
+	fs.StringVar(&logLevel, "log", "info", "log level (trace, debug, info, warn, error, critical)")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if c.Site == "" {
+		return fmt.Errorf("site is required")
+	}
+
+	config, err := loadConfig(c.ConfigPath)
+	if err != nil {
+		return err
+	}
+
+	c.Config = config
+
+	return nil
+}
+
+// Run computes the plans of all site deployments and prints them as JSON.
+func (c *PlanCommand) Run(_ context.Context) error {
+	site, err := findSite(c.Config, c.Site)
+	if err != nil {
+		return err
+	}
+
+	registerDeploymentTargets()
+
+	log := commandLogger()
+
+	results, planErr := deploy.Plan(site, log)
+
+	jsoned, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintln(c.Out, string(jsoned))
+
+	if planErr != nil {
+		return errors.New(midas.ErrorMessage(planErr))
+	}
+
+	return nil
+}
+
+// findSite returns the site with the API key or name.
+func findSite(config midas.Config, key string) (midas.Site, error) {
+	if site, ok := config.Sites[key]; ok {
+		return site, nil
+	}
+
+	for _, site := range config.Sites {
+		if site.SiteName == key {
+			return site, nil
+		}
+	}
+
+	return midas.Site{}, fmt.Errorf("site %s not found", key)
+}
+
+// commandLogger creates the logger of the subcommands, writing to stderr.
+func commandLogger() zerolog.Logger {
+	level, err := zerolog.ParseLevel(logLevel)
+	if err != nil {
+		level = zerolog.InfoLevel
+	}
+
+	return zerolog.New(zerolog.ConsoleWriter{Out: os.Stderr}).Level(level).With().Timestamp().Logger()
+}
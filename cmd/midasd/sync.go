@@ -0,0 +1,182 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/kovansky/midas"
+	"github.com/kovansky/midas/bluemonday"
+	"github.com/kovansky/midas/concurrent"
+	"github.com/kovansky/midas/deploy"
+	"github.com/kovansky/midas/snapshot"
+	"io"
+	"os"
+)
+
+// SyncCommand regenerates the content of a site from all entries fetched from the provider, removing the content of
+// the entries which don't exist anymore, and then builds and deploys the site.
+type SyncCommand struct {
+	Config     midas.Config
+	ConfigPath string
+	// Site is the API key or name of the site.
+	Site string
+	// Provider is the name of the provider to fetch the entries from, as in the sync endpoint.
+	Provider string
+
+	Out io.Writer
+}
+
+// SyncOutput is printed after the sync.
+type SyncOutput struct {
+	Sync        midas.SyncResult         `json:"sync"`
+	Deployments []midas.DeploymentResult `json:"deployments,omitempty"`
+}
+
+// runSync executes the sync subcommand.
+func runSync(ctx context.Context, args []string) error {
+	c := &SyncCommand{Out: os.Stdout}
+
+	if err := c.ParseFlags(ctx, args); err != nil {
+		return err
+	}
+
+	return c.Run(ctx)
+}
+
+// ParseFlags parses command line arguments and loads the config.
+func (c *SyncCommand) ParseFlags(_ context.Context, args []string) error {
+	fs := flag.NewFlagSet("midasd sync", flag.ContinueOnError)
+	fs.StringVar(&c.ConfigPath, "config", defaultConfigPath, "config path")
+	fs.StringVar(&c.Site, "site", "", "API key or name of the site to sync")
+	fs.StringVar(&c.Provider, "provider", "strapi", "provider to fetch the entries from")
+	fs.StringVar(&logLevel, "log", "info", "log level (trace, debug, info, warn, error, critical)")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if c.Site == "" {
+		return fmt.Errorf("site is required")
+	}
+
+	config, err := loadConfig(c.ConfigPath)
+	if err != nil {
+		return err
+	}
+
+	c.Config = config
+
+	return nil
+}
+
+// Run syncs the content, builds and deploys the site, and prints the sync and deployment results as JSON. If the build
+// fails, the content changes are rolled back.
+func (c *SyncCommand) Run(_ context.Context) error {
+	site, err := findSite(c.Config, c.Site)
+	if err != nil {
+		return err
+	}
+
+	provider, ok := providers()[c.Provider]
+	if !ok {
+		return fmt.Errorf("provider %s is not supported", c.Provider)
+	}
+
+	syncProvider, ok := provider.(midas.SyncProvider)
+	if !ok {
+		return fmt.Errorf("provider %s doesn't support sync", c.Provider)
+	}
+
+	newSiteService, ok := siteServices()[site.Service]
+	if !ok {
+		return fmt.Errorf("static site generator %s is not supported", site.Service)
+	}
+
+	registerRegistryServices()
+	registerDeploymentTargets()
+
+	midas.Sanitizer = bluemonday.NewSanitizerService()
+	midas.Concurrents = concurrent.NewList()
+
+	log := commandLogger()
+
+	payloads, err := syncProvider.FetchAll(site)
+	if err != nil {
+		return errors.New(midas.ErrorMessage(err))
+	}
+
+	siteService, err := newSiteService(site)
+	if err != nil {
+		return err
+	}
+
+	registry, err := siteService.GetRegistryService()
+	if err != nil {
+		return err
+	}
+	defer registry.CloseStorage()
+
+	result, err := siteService.Sync(payloads)
+	if err == nil {
+		log.Info().Msgf("Synced %d entries, removed %d", result.Updated, len(result.Removed))
+		err = siteService.BuildSite(false, log)
+	}
+
+	// Failed drafts build keeps the changes, as the site was already built with them.
+	if err != nil && midas.ErrorCode(err) != midas.ErrDraftsBuild {
+		if rollbackErr := siteService.Rollback(); rollbackErr != nil {
+			log.Error().Err(rollbackErr).Msg("Could not roll back the content changes")
+		}
+
+		return errors.New(midas.ErrorMessage(err))
+	}
+
+	if commitErr := siteService.Commit(); commitErr != nil {
+		log.Error().Err(commitErr).Msg("Could not commit the content changes")
+	}
+
+	if err != nil {
+		return errors.New(midas.ErrorMessage(err))
+	}
+
+	if site.Snapshots.Enabled {
+		if err = archive(site, registry); err != nil {
+			log.Error().Err(err).Msg("Could not archive the build")
+		}
+	}
+
+	results, deployErr := deploy.Run(site, log)
+
+	jsoned, err := json.MarshalIndent(SyncOutput{Sync: result, Deployments: results}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintln(c.Out, string(jsoned))
+
+	if deployErr != nil {
+		return errors.New(midas.ErrorMessage(deployErr))
+	}
+
+	return nil
+}
+
+// archive saves the snapshot of the build.
+func archive(site midas.Site, registryService midas.RegistryService) error {
+	registry, err := registryService.Snapshot()
+	if err != nil {
+		return err
+	}
+
+	_, err = snapshot.NewService(site).Create(registry)
+
+	return err
+}
@@ -0,0 +1,155 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package command
+
+import (
+	"context"
+	"github.com/kovansky/midas"
+	"github.com/kovansky/midas/concurrent"
+	"github.com/kovansky/midas/content"
+	"github.com/rs/zerolog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+)
+
+var _ midas.SiteService = (*SiteService)(nil)
+
+// SiteService builds the site with the configured command, so any generator (i.e. Next.js export, Gatsby or MkDocs)
+// can be used. The content is not written, the site has to get the data from the CMS on its own.
+type SiteService struct {
+	Site midas.Site
+
+	registry midas.RegistryService
+	journal  *content.Journal
+}
+
+func NewSiteService(config midas.Site) (midas.SiteService, error) {
+	if config.Command.Command == "" {
+		return nil, midas.Errorf(midas.ErrSiteConfig, "site %s has no build command", config.SiteName)
+	}
+
+	if _, ok := midas.RegistryServices[config.Registry.Type]; !ok {
+		return nil, midas.Errorf(midas.ErrSiteConfig, "requested registry type %s does not exit", config.Registry.Type)
+	}
+
+	siteService := SiteService{
+		Site:     config,
+		registry: midas.RegistryServices[config.Registry.Type](config),
+		journal:  &content.Journal{},
+	}
+
+	err := siteService.registry.OpenStorage()
+	if err != nil {
+		err = siteService.registry.CreateStorage()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return siteService, nil
+}
+
+func (s SiteService) GetRegistryService() (midas.RegistryService, error) {
+	return s.registry, nil
+}
+
+// BuildSite runs the build command, followed by the drafts command if building drafts is enabled. The commands have no
+// cache settings, so useCache is ignored.
+func (s SiteService) BuildSite(_ bool, _ zerolog.Logger) error {
+	err := concurrent.Run(s.Site, s.Site.Command.Command, func(ctx context.Context) *exec.Cmd {
+		return s.command(ctx, s.Site.Command)
+	})
+	if err != nil {
+		return err
+	}
+
+	if s.Site.BuildDrafts && s.Site.Command.Drafts != nil {
+		return s.BuildDrafts()
+	}
+
+	return nil
+}
+
+func (s SiteService) BuildDrafts() error {
+	drafts := *s.Site.Command.Drafts
+
+	out, err := s.command(context.Background(), drafts).CombinedOutput()
+	if err != nil {
+		return midas.Errorf(midas.ErrDraftsBuild, "%s draft build errored: %s\ncommand output: %s", drafts.Command, err, out)
+	}
+
+	return nil
+}
+
+// command returns the command of the settings, run in the working dir with the environment variables added.
+func (s SiteService) command(ctx context.Context, settings midas.CommandSettings) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, settings.Command, settings.Args...)
+
+	cmd.Dir = s.Site.RootDir
+	if filepath.IsAbs(settings.WorkDir) {
+		cmd.Dir = settings.WorkDir
+	} else if settings.WorkDir != "" {
+		cmd.Dir = filepath.Join(s.Site.RootDir, settings.WorkDir)
+	}
+
+	if len(settings.Env) > 0 {
+		names := make([]string, 0, len(settings.Env))
+		for name := range settings.Env {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		cmd.Env = os.Environ()
+		for _, name := range names {
+			cmd.Env = append(cmd.Env, name+"="+settings.Env[name])
+		}
+	}
+
+	return cmd
+}
+
+// The content is fetched by the site itself, so the content changes are accepted without writing anything.
+
+func (s SiteService) CreateEntry(_ midas.Payload) (string, error) {
+	return "", nil
+}
+
+func (s SiteService) UpdateEntry(_ midas.Payload) (string, error) {
+	return "", nil
+}
+
+func (s SiteService) DeleteEntry(_ midas.Payload) (string, error) {
+	return "", nil
+}
+
+func (s SiteService) UpdateSingle(_ midas.Payload) (string, error) {
+	return "", nil
+}
+
+func (s SiteService) DeleteSingle(_ midas.Payload) (string, error) {
+	return "", nil
+}
+
+// MirrorMedia mirrors the media library change into the media directory of the site, which is the only content written
+// for the command sites. The mirrored files are rolled back if the build fails.
+func (s SiteService) MirrorMedia(payload midas.MediaPayload) ([]string, error) {
+	return payload.Mirror(s.Site, s.journal.StageFile)
+}
+
+func (s SiteService) Commit() error {
+	return s.journal.Commit()
+}
+
+func (s SiteService) Rollback() error {
+	return s.journal.Rollback(s.registry)
+}
+
+func (s SiteService) Sync(_ []midas.Payload) (midas.SyncResult, error) {
+	return midas.SyncResult{}, nil
+}
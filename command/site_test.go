@@ -0,0 +1,87 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package command
+
+import (
+	"fmt"
+	"github.com/kovansky/midas"
+	"github.com/kovansky/midas/concurrent"
+	"github.com/kovansky/midas/testing_utils"
+	"github.com/rs/zerolog"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSiteService_BuildSite(t *testing.T) {
+	midas.Concurrents = concurrent.NewList()
+
+	root := t.TempDir()
+	log := filepath.Join(root, "build.log")
+
+	if err := os.Mkdir(filepath.Join(root, "site"), 0775); err != nil {
+		t.Fatal(err)
+	}
+
+	// The stub records the working dir, arguments and environment of each run instead of building the site.
+	stub := filepath.Join(root, "build.sh")
+	script := "#!/bin/sh\necho \"$(pwd) $@ env=$NODE_ENV\" >> " + log + "\n"
+	if err := os.WriteFile(stub, []byte(script), 0775); err != nil {
+		t.Fatal(err)
+	}
+
+	site := SiteService{Site: midas.Site{
+		SiteName:    "command",
+		RootDir:     root,
+		BuildDrafts: true,
+		Command: midas.CommandSettings{
+			Command: stub,
+			Args:    []string{"run", "build"},
+			Env:     map[string]string{"NODE_ENV": "production"},
+			WorkDir: "site",
+			Drafts:  &midas.CommandSettings{Command: stub, Args: []string{"run", "preview"}},
+		},
+	}}
+
+	if err := site.BuildSite(true, zerolog.Nop()); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The working dir is resolved by the shell, i.e. with symlinks in the temp dir path.
+	realRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := fmt.Sprintf("%s run build env=production\n%s run preview env=%s\n",
+		filepath.Join(realRoot, "site"), realRoot, os.Getenv("NODE_ENV"))
+
+	testing_utils.AssertEquals(t, string(content), expected, "Runs")
+
+	t.Run("DraftsFailure", func(t *testing.T) {
+		site.Site.Command.Drafts = &midas.CommandSettings{Command: "false"}
+
+		testing_utils.AssertEquals(t, midas.ErrorCode(site.BuildSite(true, zerolog.Nop())), midas.ErrDraftsBuild, "Error code")
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		site.Site.Command = midas.CommandSettings{Command: "false"}
+
+		testing_utils.AssertEquals(t, midas.ErrorCode(site.BuildSite(true, zerolog.Nop())), midas.ErrInternal, "Error code")
+	})
+}
+
+func TestNewSiteService(t *testing.T) {
+	_, err := NewSiteService(midas.Site{SiteName: "command"})
+
+	testing_utils.AssertEquals(t, midas.ErrorCode(err), midas.ErrSiteConfig, "Error code")
+}
@@ -0,0 +1,43 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package concurrent
+
+import (
+	"context"
+	"github.com/kovansky/midas"
+	"os/exec"
+)
+
+// Run runs the build command of the site, registered in midas.Concurrents, so it's cancelled when another build of the
+// site starts. The generator name is used in the error message of the failed build.
+func Run(site midas.Site, generator string, command func(ctx context.Context) *exec.Cmd) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cmd := command(ctx)
+
+	err := midas.Concurrents.Add(New(site, cancel))
+	if err != nil {
+		if midas.ErrorCode(err) != midas.ErrProcessNotFound {
+			return err
+		}
+	}
+
+	out, err := cmd.CombinedOutput()
+
+	select {
+	case <-ctx.Done():
+		return midas.Errorf(midas.ErrCancelled, "process cancelled")
+	default:
+		midas.Concurrents.Remove(site.SiteName)
+		if err != nil {
+			return midas.Errorf(midas.ErrInternal, "%s build errored: %s\ncommand output: %s", generator, err, out)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,173 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package content
+
+import (
+	"fmt"
+	"github.com/kovansky/midas"
+	"sort"
+)
+
+// RegistryId generates the id of the entry used in the registry. Entries with locale have it appended on multilingual
+// sites, as translations are separate files.
+func RegistryId(site midas.Site, payload midas.Payload) string {
+	if locale := Locale(site, payload); locale != "" {
+		return fmt.Sprintf("%v-%v-%s", payload.Metadata()["model"], EntryId(payload), locale)
+	}
+
+	return fmt.Sprintf("%v-%v", payload.Metadata()["model"], EntryId(payload))
+}
+
+// Locale returns the CMS locale of the entry on multilingual sites. Locales of the entries are ignored (i.e. when the
+// CMS has localization enabled) unless the site has locale settings, so monolingual sites keep their structure.
+func Locale(site midas.Site, payload midas.Payload) string {
+	if !site.Locales.Enabled() {
+		return ""
+	}
+
+	locale, _ := payload.Metadata()["locale"].(string)
+
+	return locale
+}
+
+// EntryId returns the id of the entry, stable across the events (i.e. the document id of Strapi v5 entries).
+func EntryId(payload midas.Payload) interface{} {
+	if id, ok := payload.Metadata()["entryId"]; ok && id != nil {
+		return id
+	}
+
+	return payload.Entry()["id"]
+}
+
+// Title returns the value of the title field of the entry.
+func Title(model *midas.ModelSettings, entry map[string]interface{}) interface{} {
+	if model.Fields.Title != nil {
+		return entry[*model.Fields.Title]
+	}
+
+	return entry["Title"]
+}
+
+// Date returns the publication date of the entry, or the creation date of the unpublished one.
+func Date(entry map[string]interface{}) interface{} {
+	if date := entry["publishedAt"]; date != nil {
+		return date
+	}
+
+	return entry["createdAt"]
+}
+
+// Dependency returns the dependency of the entry recorded in the registry: the related entries referenced by the
+// relation fields of the entry.
+func Dependency(model *midas.ModelSettings, payload midas.Payload) midas.Dependency {
+	dependency := midas.Dependency{
+		Model:      fmt.Sprint(payload.Metadata()["model"]),
+		Id:         fmt.Sprint(EntryId(payload)),
+		References: References(model, payload.Entry()),
+	}
+
+	if locale, ok := payload.Metadata()["locale"].(string); ok {
+		dependency.Locale = locale
+	}
+
+	return dependency
+}
+
+// References returns the related entries (as <model>-<id>) of the relation fields of the entry, sorted.
+func References(model *midas.ModelSettings, entry map[string]interface{}) []string {
+	unique := make(map[string]bool)
+	if model == nil {
+		return []string{}
+	}
+
+	for field, relatedModel := range model.Relations {
+		var related []interface{}
+
+		switch value := entry[field].(type) {
+		case map[string]interface{}:
+			related = []interface{}{value}
+		case []interface{}:
+			related = value
+		}
+
+		for _, relatedEntry := range related {
+			relatedEntry, ok := relatedEntry.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			// Strapi v5 entries are identified by the document id.
+			id := relatedEntry["documentId"]
+			if id == nil {
+				id = relatedEntry["id"]
+			}
+
+			if id != nil {
+				unique[fmt.Sprintf("%s-%v", relatedModel, id)] = true
+			}
+		}
+	}
+
+	sorted := make([]string, 0, len(unique))
+	for reference := range unique {
+		sorted = append(sorted, reference)
+	}
+	sort.Strings(sorted)
+
+	return sorted
+}
+
+// SanitizeMap iterates (recursively) through given map and passes each value through HTML sanitizer.
+func SanitizeMap(entry map[string]interface{}) map[string]interface{} {
+	output := make(map[string]interface{})
+
+	for key, value := range entry {
+		// Check value type
+		switch value.(type) {
+		case map[string]interface{}:
+			output[key] = SanitizeMap(value.(map[string]interface{}))
+			break
+		case []interface{}:
+			output[key] = sanitizeSlice(value.([]interface{}))
+			break
+		default:
+			if stringed, ok := value.(string); ok {
+				output[key] = midas.Sanitizer.Sanitize(stringed)
+			} else {
+				output[key] = value
+			}
+
+		}
+	}
+
+	return output
+}
+
+// sanitizeSlice iterates (recursively) through given slice and passes each value through HTML sanitizer.
+func sanitizeSlice(entry []interface{}) []interface{} {
+	output := make([]interface{}, len(entry))
+
+	for key, value := range entry {
+		// Check value type
+		switch value.(type) {
+		case map[string]interface{}:
+			output[key] = SanitizeMap(value.(map[string]interface{}))
+			break
+		case []interface{}:
+			output[key] = sanitizeSlice(value.([]interface{}))
+			break
+		default:
+			if stringed, ok := value.(string); ok {
+				output[key] = midas.Sanitizer.Sanitize(stringed)
+			} else {
+				output[key] = value
+			}
+		}
+	}
+
+	return output
+}
@@ -0,0 +1,116 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package content
+
+import (
+	"fmt"
+	"github.com/kovansky/midas"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// defaultFilename is the filename template used if the model doesn't define one: the slug of the title.
+const defaultFilename = "{{ slug .Title }}"
+
+var filenameFuncs = template.FuncMap{
+	// slug creates the slug of the value.
+	"slug": func(value interface{}) string {
+		return midas.CreateSlug(stringify(value))
+	},
+	// date formats the timestamp (i.e. createdAt field) with the Go layout.
+	"date": func(layout string, value interface{}) (string, error) {
+		switch value := value.(type) {
+		case time.Time:
+			return value.Format(layout), nil
+		case string:
+			parsed, err := time.Parse(time.RFC3339Nano, value)
+			if err != nil {
+				return "", err
+			}
+
+			return parsed.Format(layout), nil
+		default:
+			return "", fmt.Errorf("%v is not a date", value)
+		}
+	},
+	// default returns the value, or the fallback if the value is empty.
+	"default": func(fallback, value interface{}) interface{} {
+		if value == nil || stringify(value) == "" {
+			return fallback
+		}
+
+		return value
+	},
+}
+
+// EntryFilename returns the filename (without the extension) of the entry, generated from the filename template of the
+// model. The template gets the entry fields (Entry), metadata (Metadata) and the title (Title).
+func EntryFilename(modelName string, model *midas.ModelSettings, payload midas.Payload) (string, error) {
+	titleField := "Title"
+	if model.Fields.Title != nil {
+		titleField = *model.Fields.Title
+	}
+
+	filenameTemplate := model.Filename
+	if filenameTemplate == "" {
+		filenameTemplate = defaultFilename
+	}
+
+	tmpl, err := template.New("filename").Funcs(filenameFuncs).Option("missingkey=zero").Parse(filenameTemplate)
+	if err != nil {
+		return "", midas.Errorf(midas.ErrSiteConfig, "filename template of model %s is invalid: %s", modelName, err)
+	}
+
+	var filename strings.Builder
+	err = tmpl.Execute(&filename, struct {
+		Metadata map[string]interface{}
+		Entry    map[string]interface{}
+		Title    interface{}
+	}{payload.Metadata(), payload.Entry(), payload.Entry()[titleField]})
+	if err != nil {
+		return "", midas.Errorf(midas.ErrInvalid, "could not generate filename of model %s entry: %s", modelName, err)
+	}
+
+	// Missing fields are printed as "<no value>", even with missingkey=zero option.
+	name := strings.TrimSpace(strings.ReplaceAll(filename.String(), "<no value>", ""))
+	if name == "" || name == "." || name == ".." || strings.ContainsAny(name, `/\`) {
+		return "", midas.Errorf(midas.ErrInvalid, "filename %q of model %s entry is invalid", name, modelName)
+	}
+
+	return name, nil
+}
+
+// FreePath returns the path of the entry named filename in the output dir, which is not taken by another entry. On
+// collision, the filename is suffixed with a number. The suffix (i.e. the extension) is appended after the number. The
+// entry's own path (oldPath) is considered free.
+func FreePath(outputDir, filename, suffix, oldPath string) string {
+	outputPath := filepath.Join(outputDir, filename+suffix)
+
+	for i := 2; outputPath != oldPath && exists(outputPath); i++ {
+		outputPath = filepath.Join(outputDir, fmt.Sprintf("%s-%d%s", filename, i, suffix))
+	}
+
+	return outputPath
+}
+
+// exists tells if the file (or directory) exists.
+func exists(path string) bool {
+	_, err := os.Lstat(path)
+	return err == nil
+}
+
+// stringify returns the string representation of the value, with empty string for nil.
+func stringify(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%v", value)
+}
@@ -0,0 +1,85 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package content
+
+import (
+	"github.com/kovansky/midas"
+	"github.com/kovansky/midas/strapi"
+	"github.com/kovansky/midas/testing_utils"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEntryFilename(t *testing.T) {
+	payload, err := strapi.ParsePayload([]byte(`{
+    "event": "entry.create",
+    "createdAt": "2022-01-01T10:10:10.000Z",
+    "model": "post",
+    "entry": {
+      "id": 7,
+      "Title": "Hello World",
+      "slug": "hello",
+      "locale": "pl",
+      "createdAt": "2022-01-02T10:10:10.000Z"
+    }
+  }`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string][]string{
+		"Default":     {"", "hello-world"},
+		"Slug field":  {"{{ .Entry.slug }}", "hello"},
+		"Id":          {"{{ .Entry.id }}", "7"},
+		"Date prefix": {`{{ date "2006-01-02" .Entry.createdAt }}-{{ slug .Title }}`, "2022-01-02-hello-world"},
+		"Locale":      {"{{ slug .Title }}.{{ .Entry.locale }}", "hello-world.pl"},
+		"Fallback":    {"{{ .Entry.missing | default .Title | slug }}", "hello-world"},
+		"Missing":     {"{{ .Entry.id }}{{ .Entry.missing }}", "7"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			filename, err := EntryFilename("post", &midas.ModelSettings{Filename: test[0]}, payload)
+
+			testing_utils.AssertTable(t, map[string][]interface{}{
+				"Error":    {err, nil},
+				"Filename": {filename, test[1]},
+			})
+		})
+	}
+
+	t.Run("InvalidTemplate", func(t *testing.T) {
+		_, err := EntryFilename("post", &midas.ModelSettings{Filename: "{{ .Entry.slug "}, payload)
+
+		testing_utils.AssertEquals(t, midas.ErrorCode(err), midas.ErrSiteConfig, "Error code")
+	})
+
+	t.Run("PathSeparator", func(t *testing.T) {
+		_, err := EntryFilename("post", &midas.ModelSettings{Filename: "../{{ .Entry.slug }}"}, payload)
+
+		testing_utils.AssertEquals(t, midas.ErrorCode(err), midas.ErrInvalid, "Error code")
+	})
+}
+
+func TestFreePath(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, name := range []string{"post.html", "post-2.html"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte{}, 0664); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	testing_utils.AssertTable(t, map[string][]interface{}{
+		"Free":      {FreePath(dir, "other", ".html", ""), filepath.Join(dir, "other.html")},
+		"Collision": {FreePath(dir, "post", ".html", ""), filepath.Join(dir, "post-3.html")},
+		"Own path":  {FreePath(dir, "post", ".html", filepath.Join(dir, "post-2.html")), filepath.Join(dir, "post-2.html")},
+		"Language":  {FreePath(dir, "post", ".pl.html", ""), filepath.Join(dir, "post.pl.html")},
+		"Bundle":    {FreePath(dir, "post", "", ""), filepath.Join(dir, "post")},
+	})
+}
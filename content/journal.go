@@ -0,0 +1,218 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package content
+
+import (
+	"fmt"
+	"github.com/kovansky/midas"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Journal records the content files and registry entries changed since the last commit, together with their previous
+// state, so the changes can be rolled back. It's shared by the site services writing the content files.
+type Journal struct {
+	// backupDir holds the copies of changed files. Created on the first change.
+	backupDir string
+	files     []stagedFile
+	entries   []stagedEntry
+}
+
+type stagedFile struct {
+	path string
+	// backup is the path of the file copy, empty if the file didn't exist.
+	backup string
+}
+
+type stagedEntry struct {
+	id string
+	// filename is the previous filename of the entry, nil if the entry didn't exist.
+	filename *string
+	// dependency is the previous dependency of the entry, nil if the entry had no references.
+	dependency *midas.Dependency
+}
+
+// StageFile saves the current state of the file (or directory) before it's changed. Files are saved once per
+// transaction, so the state from before the first change is restored.
+func (j *Journal) StageFile(path string) error {
+	if path == "" {
+		return nil
+	}
+
+	for _, file := range j.files {
+		if file.path == path {
+			return nil
+		}
+	}
+
+	staged := stagedFile{path: path}
+
+	if _, err := os.Lstat(path); err == nil {
+		if j.backupDir == "" {
+			if j.backupDir, err = os.MkdirTemp("", "midas-journal-"); err != nil {
+				return err
+			}
+		}
+
+		staged.backup = filepath.Join(j.backupDir, fmt.Sprint(len(j.files)))
+		if err = copyPath(path, staged.backup); err != nil {
+			return fmt.Errorf("could not back up %s: %v", path, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	j.files = append(j.files, staged)
+
+	return nil
+}
+
+// StageEntry saves the current state of the registry entry (and its dependency) before it's changed.
+func (j *Journal) StageEntry(registry midas.RegistryService, id string) {
+	for _, entry := range j.entries {
+		if entry.id == id {
+			return
+		}
+	}
+
+	staged := stagedEntry{id: id}
+	if filename, err := registry.ReadEntry(id); err == nil {
+		staged.filename = &filename
+	}
+	if dependency, err := registry.ReadDependency(id); err == nil {
+		staged.dependency = &dependency
+	}
+
+	j.entries = append(j.entries, staged)
+}
+
+// Rollback restores the content files and the entries of the registry changed since the last commit or rollback. Only
+// the changed entries are restored, so changes made to the registry by others in the meantime are kept.
+func (j *Journal) Rollback(registry midas.RegistryService) error {
+	// Restore in reverse order, in case a path was staged inside another one.
+	for i := len(j.files) - 1; i >= 0; i-- {
+		file := j.files[i]
+
+		if err := os.RemoveAll(file.path); err != nil {
+			return err
+		}
+
+		if file.backup != "" {
+			if err := copyPath(file.backup, file.path); err != nil {
+				return fmt.Errorf("could not restore %s: %v", file.path, err)
+			}
+		}
+	}
+
+	for _, entry := range j.entries {
+		_, err := registry.ReadEntry(entry.id)
+		exists := err == nil
+
+		switch {
+		case entry.filename == nil && exists:
+			err = registry.DeleteEntry(entry.id)
+		case entry.filename != nil && exists:
+			err = registry.UpdateEntry(entry.id, *entry.filename)
+		case entry.filename != nil:
+			err = registry.CreateEntry(entry.id, *entry.filename)
+		default:
+			err = nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		dependency := midas.Dependency{}
+		if entry.dependency != nil {
+			dependency = *entry.dependency
+		}
+
+		if err = registry.SetDependency(entry.id, dependency); err != nil {
+			return err
+		}
+	}
+
+	if len(j.entries) > 0 {
+		if err := registry.Flush(); err != nil {
+			return err
+		}
+	}
+
+	return j.Commit()
+}
+
+// Commit forgets the changes staged since the last commit or rollback, and removes the backups.
+func (j *Journal) Commit() error {
+	j.files, j.entries = nil, nil
+
+	if j.backupDir == "" {
+		return nil
+	}
+
+	err := os.RemoveAll(j.backupDir)
+	j.backupDir = ""
+
+	return err
+}
+
+// copyPath copies the file or directory (recursively) from src to dst, keeping the modes and modification times.
+func copyPath(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		switch {
+		case info.IsDir():
+			if err = os.MkdirAll(target, info.Mode().Perm()); err != nil {
+				return err
+			}
+		case info.Mode().IsRegular():
+			if err = copyFile(path, target, info.Mode().Perm()); err != nil {
+				return err
+			}
+		default:
+			return nil
+		}
+
+		return os.Chtimes(target, info.ModTime(), info.ModTime())
+	})
+}
+
+func copyFile(src, dst string, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0775); err != nil {
+		return err
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer func(in *os.File) {
+		_ = in.Close()
+	}(in)
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+
+	if _, err = io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+
+	return out.Close()
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package content
+
+import (
+	"github.com/kovansky/midas"
+	"github.com/kovansky/midas/jsonfile"
+	"github.com/kovansky/midas/testing_utils"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestJournal(t *testing.T) {
+	root := t.TempDir()
+	registry := jsonfile.NewRegistryService(midas.Site{
+		RootDir:  root,
+		Registry: midas.RegistrySettings{Type: "jsonfile", Location: "registry.json"},
+	})
+	if err := registry.CreateStorage(); err != nil {
+		t.Fatal(err)
+	}
+
+	existing := filepath.Join(root, "existing.md")
+	created := filepath.Join(root, "created.md")
+
+	if err := os.WriteFile(existing, []byte("old"), 0664); err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.CreateEntry("post-1", existing); err != nil {
+		t.Fatal(err)
+	}
+
+	// change stages and changes the files and registry entries.
+	change := func(t *testing.T, journal *Journal) {
+		for _, path := range []string{existing, created} {
+			if err := journal.StageFile(path); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, []byte("new"), 0664); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		journal.StageEntry(registry, "post-1")
+		journal.StageEntry(registry, "post-2")
+
+		if err := registry.UpdateEntry("post-1", created); err != nil {
+			t.Fatal(err)
+		}
+		if err := registry.CreateEntry("post-2", existing); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("Rollback", func(t *testing.T) {
+		journal := &Journal{}
+		change(t, journal)
+
+		if err := journal.Rollback(registry); err != nil {
+			t.Fatal(err)
+		}
+
+		content, _ := os.ReadFile(existing)
+		_, statErr := os.Stat(created)
+		filename, _ := registry.ReadEntry("post-1")
+		_, entryErr := registry.ReadEntry("post-2")
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"File restored":     {string(content), "old"},
+			"File removed":      {os.IsNotExist(statErr), true},
+			"Entry restored":    {filename, existing},
+			"Entry removed":     {entryErr != nil, true},
+			"Backups removed":   {journal.backupDir, ""},
+			"Changes forgotten": {len(journal.files) + len(journal.entries), 0},
+		})
+	})
+
+	t.Run("Commit", func(t *testing.T) {
+		journal := &Journal{}
+		change(t, journal)
+
+		backupDir := journal.backupDir
+
+		if err := journal.Commit(); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := journal.Rollback(registry); err != nil {
+			t.Fatal(err)
+		}
+
+		content, _ := os.ReadFile(existing)
+		_, statErr := os.Stat(backupDir)
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Change kept":     {string(content), "new"},
+			"Backups removed": {os.IsNotExist(statErr), true},
+		})
+	})
+}
@@ -0,0 +1,221 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package content
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/BurntSushi/toml"
+	"github.com/kovansky/midas"
+	"gopkg.in/yaml.v3"
+	"math"
+	"time"
+)
+
+// RenderMarkdown renders the entry as markdown content: entry fields go to the front matter and the body field becomes
+// the content. The predefined front matter variables of the generator are added, unless the entry has fields with the
+// same names.
+func RenderMarkdown(model *midas.ModelSettings, payload midas.Payload, predefined map[string]interface{}) ([]byte, error) {
+	frontMatter := FrontMatter(model, payload.Entry())
+
+	for key, value := range predefined {
+		if _, ok := frontMatter[key]; ok {
+			continue
+		}
+
+		if value = FrontMatterValue(value); value != nil {
+			frontMatter[key] = value
+		}
+	}
+
+	return Markdown(model, frontMatter, payload.Entry())
+}
+
+// FrontMatter returns the entry fields (except the body field) converted to their front matter types. The HTML fields
+// are passed through the HTML sanitizer, as the templates render them unescaped.
+func FrontMatter(model *midas.ModelSettings, entry map[string]interface{}) map[string]interface{} {
+	bodyField := bodyField(model)
+	frontMatter := make(map[string]interface{})
+
+	for key, value := range entry {
+		if key == bodyField {
+			continue
+		}
+
+		if html, ok := value.(string); ok && IsHTMLField(model, key) {
+			value = midas.Sanitizer.Sanitize(html)
+		}
+
+		if value = FrontMatterValue(value); value != nil {
+			frontMatter[key] = value
+		}
+	}
+
+	return frontMatter
+}
+
+// Markdown encodes the front matter in the front matter format of the model, followed by the body field of the entry
+// as the content.
+func Markdown(model *midas.ModelSettings, frontMatter map[string]interface{}, entry map[string]interface{}) ([]byte, error) {
+	encoded, err := EncodeFrontMatter(model.FrontMatter, frontMatter)
+	if err != nil {
+		return nil, err
+	}
+
+	var content bytes.Buffer
+	content.Write(encoded)
+
+	bodyField := bodyField(model)
+	if body, ok := entry[bodyField]; ok && body != nil {
+		content.WriteString("\n")
+
+		body := fmt.Sprintf("%v", body)
+		if IsHTMLField(model, bodyField) {
+			body = midas.Sanitizer.Sanitize(body)
+		}
+
+		content.WriteString(body)
+		content.WriteString("\n")
+	}
+
+	return content.Bytes(), nil
+}
+
+// bodyField returns the field used as the content of markdown output.
+func bodyField(model *midas.ModelSettings) string {
+	if model.Fields.Body != nil {
+		return *model.Fields.Body
+	}
+
+	return "Content"
+}
+
+// RenderData renders the entry as JSON data file, with values passed through the HTML sanitizer. The predefined
+// fields of the generator are added, unless the entry has fields with the same names.
+func RenderData(payload midas.Payload, predefined map[string]interface{}) ([]byte, error) {
+	data := SanitizeMap(payload.Entry())
+
+	for key, value := range predefined {
+		if _, ok := data[key]; !ok && value != nil {
+			data[key] = value
+		}
+	}
+
+	var encoded bytes.Buffer
+	encoder := json.NewEncoder(&encoded)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(data); err != nil {
+		return nil, err
+	}
+
+	return encoded.Bytes(), nil
+}
+
+// RenderJSON renders the data file of the entry as JSON, with values passed through the HTML sanitizer.
+func RenderJSON(payload midas.Payload) ([]byte, error) {
+	payload.SetEntry(SanitizeMap(payload.Entry()))
+
+	return payload.MarshalJSON()
+}
+
+// EncodeFrontMatter encodes the front matter in the given format, including the delimiters.
+func EncodeFrontMatter(format string, frontMatter map[string]interface{}) ([]byte, error) {
+	switch format {
+	case "", midas.FrontMatterYAML:
+		encoded, err := yaml.Marshal(frontMatter)
+		if err != nil {
+			return nil, err
+		}
+
+		return append(append([]byte("---\n"), encoded...), "---\n"...), nil
+	case midas.FrontMatterTOML:
+		var encoded bytes.Buffer
+		encoded.WriteString("+++\n")
+
+		encoder := toml.NewEncoder(&encoded)
+		encoder.Indent = ""
+
+		if err := encoder.Encode(frontMatter); err != nil {
+			return nil, err
+		}
+
+		encoded.WriteString("+++\n")
+
+		return encoded.Bytes(), nil
+	case midas.FrontMatterJSON:
+		var encoded bytes.Buffer
+		encoder := json.NewEncoder(&encoded)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+
+		if err := encoder.Encode(frontMatter); err != nil {
+			return nil, err
+		}
+
+		return encoded.Bytes(), nil
+	default:
+		return nil, midas.Errorf(midas.ErrSiteConfig, "front matter format %s is not supported", format)
+	}
+}
+
+// FrontMatterValue converts the value decoded from the payload JSON to its front matter type: whole numbers become
+// integers and timestamps become dates. Nested components and arrays are converted recursively, and null values are
+// dropped (returned as nil), as they can't be represented in every format.
+func FrontMatterValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		converted := make(map[string]interface{})
+		for key, nested := range value {
+			if nested = FrontMatterValue(nested); nested != nil {
+				converted[key] = nested
+			}
+		}
+
+		return converted
+	case []interface{}:
+		converted := make([]interface{}, 0, len(value))
+		for _, nested := range value {
+			if nested = FrontMatterValue(nested); nested != nil {
+				converted = append(converted, nested)
+			}
+		}
+
+		return converted
+	case float64:
+		if value == math.Trunc(value) && math.Abs(value) < 1<<53 {
+			return int64(value)
+		}
+
+		return value
+	case string:
+		if date, err := time.Parse(time.RFC3339Nano, value); err == nil {
+			return date
+		}
+
+		return value
+	default:
+		return value
+	}
+}
+
+// IsHTMLField tells if the field is configured as HTML field of the model.
+func IsHTMLField(model *midas.ModelSettings, field string) bool {
+	if model.Fields.HTML == nil {
+		return false
+	}
+
+	for _, htmlField := range *model.Fields.HTML {
+		if htmlField == field {
+			return true
+		}
+	}
+
+	return false
+}
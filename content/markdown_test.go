@@ -0,0 +1,34 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package content
+
+import (
+	"github.com/kovansky/midas"
+	"github.com/kovansky/midas/bluemonday"
+	"github.com/kovansky/midas/testing_utils"
+	"testing"
+)
+
+func TestFrontMatter(t *testing.T) {
+	midas.Sanitizer = bluemonday.NewSanitizerService()
+
+	entry := map[string]interface{}{
+		"Title":   "<b>Hello</b>",
+		"Lead":    `<p>Intro</p><script>alert("xss")</script>`,
+		"Content": "# Hello",
+	}
+	model := &midas.ModelSettings{}
+	model.Fields.HTML = &[]string{"Lead"}
+
+	frontMatter := FrontMatter(model, entry)
+
+	testing_utils.AssertTable(t, map[string][]interface{}{
+		"HTML field":  {frontMatter["Lead"], "<p>Intro</p>"},
+		"Other field": {frontMatter["Title"], "<b>Hello</b>"},
+		"Body":        {frontMatter["Content"], nil},
+	})
+}
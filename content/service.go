@@ -0,0 +1,478 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package content
+
+import (
+	"fmt"
+	"github.com/kovansky/midas"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Layout places and renders the content files in the conventions of the generator.
+type Layout interface {
+	// EntryPath returns the directory (absolute or relative to the root dir), the filename and the extension of the
+	// collection type entry. The filename is suffixed with a number if it's taken by another entry.
+	EntryPath(modelName string, model *midas.ModelSettings, payload midas.Payload) (dir, filename, extension string, err error)
+	// RenderEntry renders the content file of the collection type entry.
+	RenderEntry(model *midas.ModelSettings, payload midas.Payload) ([]byte, error)
+	// SinglePath returns the path (absolute or relative to the root dir) of the data file of the single type in the
+	// locale (empty for the entries without locale).
+	SinglePath(modelName string, model *midas.ModelSettings, locale string) string
+	// RenderSingle renders the data file of the single type.
+	RenderSingle(model *midas.ModelSettings, payload midas.Payload) ([]byte, error)
+}
+
+// EntryWriter is implemented by the layouts writing the entries on their own, i.e. as directories with media files.
+// Other layouts have the rendered entry written to the output path.
+type EntryWriter interface {
+	WriteEntry(model *midas.ModelSettings, payload midas.Payload, outputPath string) error
+}
+
+// Service writes the content files of the entries in the layout of the generator, tracking them in the registry. The
+// site services of the generators embed it, adding the build.
+type Service struct {
+	Site   midas.Site
+	Layout Layout
+
+	registry midas.RegistryService
+	journal  *Journal
+}
+
+func NewService(config midas.Site, layout Layout) (Service, error) {
+	if _, ok := midas.RegistryServices[config.Registry.Type]; !ok {
+		return Service{}, midas.Errorf(midas.ErrSiteConfig, "requested registry type %s does not exit", config.Registry.Type)
+	}
+
+	service := Service{
+		Site:     config,
+		Layout:   layout,
+		registry: midas.RegistryServices[config.Registry.Type](config),
+		journal:  &Journal{},
+	}
+
+	err := service.registry.OpenStorage()
+	if err != nil {
+		err = service.registry.CreateStorage()
+		if err != nil {
+			return Service{}, err
+		}
+	}
+
+	return service, nil
+}
+
+func (s Service) GetRegistryService() (midas.RegistryService, error) {
+	return s.registry, nil
+}
+
+// CreateEntry writes the entry to a new file. If the entry is already in the registry, its file is replaced instead.
+func (s Service) CreateEntry(payload midas.Payload) (string, error) {
+	return s.writeEntry(payload)
+}
+
+// UpdateEntry replaces the file of the entry. The file is moved if the path of the entry changed.
+func (s Service) UpdateEntry(payload midas.Payload) (string, error) {
+	return s.writeEntry(payload)
+}
+
+// writeEntry renders the entry and writes it to the path given by the layout, replacing the previous file of the entry.
+func (s Service) writeEntry(payload midas.Payload) (string, error) {
+	modelName := payload.Metadata()["model"].(string)
+	model, _ := s.getModel(modelName)
+
+	if model == nil || model.OutputDir == "false" {
+		return "", nil
+	}
+
+	outputDir, filename, extension, err := s.Layout.EntryPath(modelName, model, payload)
+	if err != nil {
+		return "", err
+	}
+	outputDir = s.path(outputDir)
+
+	entryId, err := s.entryId(payload)
+	if err != nil {
+		return "", err
+	}
+	s.journal.StageEntry(s.registry, entryId)
+
+	oldPath, err := s.registry.ReadEntry(entryId)
+	// Registries not keeping the files (i.e. none) return an empty path. The entry isn't registered then, and as the
+	// update can't be told from the collision, the entry owns its path.
+	tracked := err != nil || oldPath != ""
+	inRegistry := err == nil && oldPath != ""
+
+	if err = os.MkdirAll(outputDir, 0775); err != nil {
+		return "", err
+	}
+
+	outputPath := filepath.Join(outputDir, filename+extension)
+	if tracked {
+		outputPath = FreePath(outputDir, filename, extension, oldPath)
+	}
+
+	if err = s.journal.StageFile(outputPath); err != nil {
+		return "", err
+	}
+	if err = s.journal.StageFile(oldPath); err != nil {
+		return "", err
+	}
+
+	// Write the entry before removing the old one, so it's not lost on error
+	if err = s.write(model, payload, outputPath); err != nil {
+		return "", err
+	}
+
+	if oldPath != "" && oldPath != outputPath {
+		if err = os.RemoveAll(oldPath); err != nil {
+			return "", err
+		}
+	}
+
+	if inRegistry {
+		err = s.registry.UpdateEntry(entryId, outputPath)
+	} else {
+		err = s.registry.CreateEntry(entryId, outputPath)
+	}
+	if err != nil {
+		return outputPath, err
+	}
+	if err = s.registry.SetDependency(entryId, Dependency(model, payload)); err != nil {
+		return outputPath, err
+	}
+	if err = s.registry.Flush(); err != nil {
+		return outputPath, err
+	}
+
+	return outputPath, nil
+}
+
+// write writes the entry to the output path, with the layout's writer if it has one.
+func (s Service) write(model *midas.ModelSettings, payload midas.Payload, outputPath string) error {
+	if writer, ok := s.Layout.(EntryWriter); ok {
+		return writer.WriteEntry(model, payload, outputPath)
+	}
+
+	rendered, err := s.Layout.RenderEntry(model, payload)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(outputPath, rendered, 0664)
+}
+
+func (s Service) DeleteEntry(payload midas.Payload) (string, error) {
+	entryId, err := s.entryId(payload)
+	if err != nil {
+		return "", err
+	}
+
+	return s.removeEntry(entryId)
+}
+
+// entryId returns the registry id of the entry. Translations registered before the locale was added to the registry
+// ids (as model-id) are moved to their localized id, so their files are replaced instead of duplicated.
+func (s Service) entryId(payload midas.Payload) (string, error) {
+	entryId := RegistryId(s.Site, payload)
+	legacyId := fmt.Sprintf("%v-%v", payload.Metadata()["model"], EntryId(payload))
+	if entryId == legacyId {
+		return entryId, nil
+	}
+
+	if _, err := s.registry.ReadEntry(entryId); err == nil {
+		return entryId, nil
+	}
+
+	path, err := s.registry.ReadEntry(legacyId)
+	if err != nil {
+		return entryId, nil
+	}
+
+	s.journal.StageEntry(s.registry, entryId)
+	s.journal.StageEntry(s.registry, legacyId)
+
+	if err = s.registry.CreateEntry(entryId, path); err != nil {
+		return "", err
+	}
+	if err = s.registry.DeleteEntry(legacyId); err != nil {
+		return "", err
+	}
+	if err = s.registry.SetDependency(legacyId, midas.Dependency{}); err != nil {
+		return "", err
+	}
+
+	return entryId, s.registry.Flush()
+}
+
+// removeEntry removes the file of the registry entry, and the entry itself.
+func (s Service) removeEntry(entryId string) (string, error) {
+	entryPath, err := s.registry.ReadEntry(entryId)
+	if err != nil {
+		return "", err
+	}
+
+	if err = s.journal.StageFile(entryPath); err != nil {
+		return "", err
+	}
+	s.journal.StageEntry(s.registry, entryId)
+
+	if err = os.RemoveAll(entryPath); err != nil {
+		return "", err
+	}
+
+	if err = s.registry.DeleteEntry(entryId); err != nil {
+		return entryPath, err
+	}
+	if err = s.registry.SetDependency(entryId, midas.Dependency{}); err != nil {
+		return entryPath, err
+	}
+	if err = s.registry.Flush(); err != nil {
+		return entryPath, err
+	}
+
+	return entryPath, nil
+}
+
+// UpdateSingle writes the data file of the single type.
+func (s Service) UpdateSingle(payload midas.Payload) (string, error) {
+	modelName := payload.Metadata()["model"].(string)
+	model, _ := s.getModel(modelName)
+	if model == nil {
+		return "", nil
+	}
+
+	outputPath := s.singlePath(payload)
+
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0775); err != nil {
+		return "", err
+	}
+
+	if err := s.journal.StageFile(outputPath); err != nil {
+		return "", err
+	}
+	entryId := RegistryId(s.Site, payload)
+	s.journal.StageEntry(s.registry, entryId)
+
+	rendered, err := s.Layout.RenderSingle(model, payload)
+	if err != nil {
+		return "", err
+	}
+
+	if err = os.WriteFile(outputPath, rendered, 0664); err != nil {
+		return "", err
+	}
+
+	// Record the related entries, so the data is regenerated when they change
+	if err = s.registry.SetDependency(entryId, Dependency(model, payload)); err != nil {
+		return outputPath, err
+	}
+	if err = s.registry.Flush(); err != nil {
+		return outputPath, err
+	}
+
+	return outputPath, nil
+}
+
+// DeleteSingle removes the data file of the single type. If the file doesn't exist, there is nothing to delete, and
+// an empty path is returned.
+func (s Service) DeleteSingle(payload midas.Payload) (string, error) {
+	modelName := payload.Metadata()["model"].(string)
+	model, _ := s.getModel(modelName)
+	if model == nil {
+		return "", nil
+	}
+
+	outputPath := s.singlePath(payload)
+	if !exists(outputPath) {
+		return "", nil
+	}
+
+	if err := s.journal.StageFile(outputPath); err != nil {
+		return "", err
+	}
+	entryId := RegistryId(s.Site, payload)
+	s.journal.StageEntry(s.registry, entryId)
+
+	if err := os.Remove(outputPath); err != nil {
+		return "", err
+	}
+
+	if err := s.registry.SetDependency(entryId, midas.Dependency{}); err != nil {
+		return outputPath, err
+	}
+	if err := s.registry.Flush(); err != nil {
+		return outputPath, err
+	}
+
+	return outputPath, nil
+}
+
+// MirrorMedia mirrors the media library change into the media directory of the site. The mirrored files are staged in
+// the journal, so they're rolled back with the content.
+func (s Service) MirrorMedia(payload midas.MediaPayload) ([]string, error) {
+	return payload.Mirror(s.Site, s.journal.StageFile)
+}
+
+// Commit accepts the content changes made since the last commit or rollback.
+func (s Service) Commit() error {
+	return s.journal.Commit()
+}
+
+// Rollback restores the content files and registry entries changed since the last commit or rollback.
+func (s Service) Rollback() error {
+	return s.journal.Rollback(s.registry)
+}
+
+// Sync regenerates the files of all entries, and removes the files of the collection type entries and single types
+// missing from the payloads. Files not tracked in the registry and entries of types missing from the config are kept.
+func (s Service) Sync(payloads []midas.Payload) (midas.SyncResult, error) {
+	result := midas.SyncResult{Removed: []string{}}
+
+	syncedEntries := make(map[string]bool)
+	syncedSingles := make(map[string]bool)
+	locales := s.locales()
+
+	for _, payload := range payloads {
+		locales[Locale(s.Site, payload)] = true
+
+		modelName, _ := payload.Metadata()["model"].(string)
+		model, isSingle := s.getModel(modelName)
+		if model == nil {
+			continue
+		}
+
+		var err error
+		if isSingle {
+			syncedSingles[s.singlePath(payload)] = true
+			_, err = s.UpdateSingle(payload)
+		} else {
+			syncedEntries[RegistryId(s.Site, payload)] = true
+			_, err = s.UpdateEntry(payload)
+		}
+
+		if err != nil {
+			return result, err
+		}
+
+		result.Updated++
+	}
+
+	// The registry is read after the updates, as they move the entries registered without locale to localized ids.
+	registry, err := s.registry.Snapshot()
+	if err != nil {
+		return result, err
+	}
+
+	ids := make([]string, 0, len(registry))
+	for id := range registry {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		if syncedEntries[id] || s.entryModel(id) == "" {
+			continue
+		}
+
+		path, err := s.removeEntry(id)
+		if err != nil {
+			return result, err
+		}
+
+		result.Removed = append(result.Removed, path)
+	}
+
+	// Single types are removed in every locale, as their files are not tracked in the registry.
+	singlePaths := make(map[string]bool)
+	for modelName, model := range s.Site.SingleTypes {
+		for locale := range locales {
+			singlePaths[s.path(s.Layout.SinglePath(modelName, &model, locale))] = true
+		}
+	}
+
+	paths := make([]string, 0, len(singlePaths))
+	for path := range singlePaths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		if syncedSingles[path] || !exists(path) {
+			continue
+		}
+
+		if err = s.journal.StageFile(path); err != nil {
+			return result, err
+		}
+		if err = os.Remove(path); err != nil {
+			return result, err
+		}
+
+		result.Removed = append(result.Removed, path)
+	}
+
+	return result, nil
+}
+
+// locales returns the CMS locales of the site known from the locale settings: the mapped locales and the default
+// language, along with the empty locale of the entries without one.
+func (s Service) locales() map[string]bool {
+	locales := map[string]bool{"": true}
+
+	if s.Site.Locales.Default != "" {
+		locales[s.Site.Locales.Default] = true
+	}
+	for locale := range s.Site.Locales.Mapping {
+		locales[locale] = true
+	}
+
+	return locales
+}
+
+// singlePath returns the absolute path of the data file of the single type, in the locale of the entry.
+func (s Service) singlePath(payload midas.Payload) string {
+	modelName := payload.Metadata()["model"].(string)
+	model, _ := s.getModel(modelName)
+	return s.path(s.Layout.SinglePath(modelName, model, Locale(s.Site, payload)))
+}
+
+// entryModel returns the collection type of the registry entry, or empty string if it belongs to none of the configured
+// types. Model names may contain dashes, so the longest matching name is used.
+func (s Service) entryModel(entryId string) string {
+	var entryModel string
+
+	for modelName := range s.Site.CollectionTypes {
+		if strings.HasPrefix(entryId, modelName+"-") && len(modelName) > len(entryModel) {
+			entryModel = modelName
+		}
+	}
+
+	return entryModel
+}
+
+// getModel returns a model from any type (collection or single), and true if model is single or false otherwise.
+func (s Service) getModel(model string) (*midas.ModelSettings, bool) {
+	if m, ok := s.Site.CollectionTypes[model]; ok {
+		return &m, false
+	} else if m, ok := s.Site.SingleTypes[model]; ok {
+		return &m, true
+	}
+
+	return nil, true
+}
+
+// path returns the absolute path of the path relative to the root dir.
+func (s Service) path(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+
+	return filepath.Join(s.Site.RootDir, path)
+}
@@ -0,0 +1,364 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package content
+
+import (
+	"fmt"
+	"github.com/kovansky/midas"
+	"github.com/kovansky/midas/jsonfile"
+	"github.com/kovansky/midas/mock"
+	"github.com/kovansky/midas/none"
+	"github.com/kovansky/midas/testing_utils"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// textLayout writes the titles of the entries as text files, named after the title.
+type textLayout struct{}
+
+func (l textLayout) EntryPath(_ string, model *midas.ModelSettings, payload midas.Payload) (string, string, string, error) {
+	return model.OutputDir, strings.ToLower(fmt.Sprint(payload.Entry()["Title"])), ".txt", nil
+}
+
+func (l textLayout) RenderEntry(_ *midas.ModelSettings, payload midas.Payload) ([]byte, error) {
+	return []byte(fmt.Sprint(payload.Entry()["Title"])), nil
+}
+
+func (l textLayout) SinglePath(modelName string, model *midas.ModelSettings, locale string) string {
+	if locale != "" {
+		modelName += "." + locale
+	}
+
+	return filepath.Join(model.OutputDir, modelName+".txt")
+}
+
+func (l textLayout) RenderSingle(model *midas.ModelSettings, payload midas.Payload) ([]byte, error) {
+	return l.RenderEntry(model, payload)
+}
+
+func newService(t *testing.T, site midas.Site) Service {
+	midas.RegistryServices = map[string]func(site midas.Site) midas.RegistryService{
+		"jsonfile": jsonfile.NewRegistryService,
+	}
+	site.Registry = midas.RegistrySettings{Type: "jsonfile", Location: "registry.json"}
+
+	service, err := NewService(site, textLayout{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return service
+}
+
+func entry(model string, id int, title string, metadata ...interface{}) midas.Payload {
+	return mock.NewPayload(model, map[string]interface{}{"id": id, "Title": title}, metadata...)
+}
+
+func snapshot(t *testing.T, service Service) midas.Registry {
+	snapshot, err := service.registry.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return snapshot
+}
+
+func readFile(t *testing.T, path string) string {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(content)
+}
+
+func TestService_Entries(t *testing.T) {
+	root := t.TempDir()
+	service := newService(t, midas.Site{
+		RootDir: root,
+		CollectionTypes: map[string]midas.ModelSettings{
+			"post":   {OutputDir: "posts", Relations: map[string]string{"author": "author"}},
+			"hidden": {OutputDir: "false"},
+		},
+	})
+
+	firstPath := filepath.Join(root, "posts", "first.txt")
+
+	t.Run("Create", func(t *testing.T) {
+		payload := mock.NewPayload("post", map[string]interface{}{"id": 1, "Title": "First", "author": map[string]interface{}{"id": 3}})
+
+		outputPath, err := service.CreateEntry(payload)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		dependency, err := service.registry.ReadDependency("post-1")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Path":       {outputPath, firstPath},
+			"Content":    {readFile(t, outputPath), "First"},
+			"Registry":   {snapshot(t, service)["post-1"], firstPath},
+			"References": {strings.Join(dependency.References, ","), "author-3"},
+		})
+	})
+
+	t.Run("Collision", func(t *testing.T) {
+		outputPath, err := service.CreateEntry(entry("post", 2, "First"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testing_utils.AssertEquals(t, outputPath, filepath.Join(root, "posts", "first-2.txt"), "Path")
+	})
+
+	t.Run("Rename", func(t *testing.T) {
+		outputPath, err := service.UpdateEntry(entry("post", 1, "Renamed"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, statErr := os.Stat(firstPath)
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Path":        {outputPath, filepath.Join(root, "posts", "renamed.txt")},
+			"Old removed": {os.IsNotExist(statErr), true},
+			"Registry":    {snapshot(t, service)["post-1"], outputPath},
+		})
+	})
+
+	t.Run("NotGenerated", func(t *testing.T) {
+		outputPath, err := service.CreateEntry(entry("hidden", 4, "Hidden"))
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Error": {err, nil},
+			"Path":  {outputPath, ""},
+		})
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		outputPath, err := service.DeleteEntry(entry("post", 2, "First"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, statErr := os.Stat(outputPath)
+		_, registered := snapshot(t, service)["post-2"]
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Removed":      {os.IsNotExist(statErr), true},
+			"Unregistered": {registered, false},
+		})
+	})
+
+	t.Run("Rollback", func(t *testing.T) {
+		if err := service.Commit(); err != nil {
+			t.Fatal(err)
+		}
+
+		renamedPath := filepath.Join(root, "posts", "renamed.txt")
+
+		if _, err := service.UpdateEntry(entry("post", 1, "Again")); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := service.CreateEntry(entry("post", 5, "Created")); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := service.Rollback(); err != nil {
+			t.Fatal(err)
+		}
+
+		registry := snapshot(t, service)
+		_, statErr := os.Stat(filepath.Join(root, "posts", "created.txt"))
+		_, registered := registry["post-5"]
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"File restored":    {readFile(t, renamedPath), "Renamed"},
+			"Entry restored":   {registry["post-1"], renamedPath},
+			"New file removed": {os.IsNotExist(statErr), true},
+			"New entry":        {registered, false},
+		})
+	})
+}
+
+func TestService_NoneRegistry(t *testing.T) {
+	root := t.TempDir()
+	midas.RegistryServices = map[string]func(site midas.Site) midas.RegistryService{
+		"none": none.NewRegistryService,
+	}
+
+	service, err := NewService(midas.Site{
+		RootDir:         root,
+		Registry:        midas.RegistrySettings{Type: "none"},
+		CollectionTypes: map[string]midas.ModelSettings{"post": {OutputDir: "posts"}},
+	}, textLayout{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = service.CreateEntry(entry("post", 1, "First")); err != nil {
+		t.Fatal(err)
+	}
+
+	outputPath, err := service.UpdateEntry(entry("post", 1, "First"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, statErr := os.Stat(filepath.Join(root, "posts", "first-2.txt"))
+
+	testing_utils.AssertTable(t, map[string][]interface{}{
+		"Path":      {outputPath, filepath.Join(root, "posts", "first.txt")},
+		"No suffix": {os.IsNotExist(statErr), true},
+	})
+}
+
+func TestService_Singles(t *testing.T) {
+	root := t.TempDir()
+	service := newService(t, midas.Site{
+		RootDir:     root,
+		Locales:     midas.LocaleSettings{Default: "en"},
+		SingleTypes: map[string]midas.ModelSettings{"homepage": {OutputDir: "data"}},
+	})
+
+	outputPath, err := service.UpdateSingle(entry("homepage", 1, "Home", "locale", "pl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testing_utils.AssertTable(t, map[string][]interface{}{
+		"Path":    {outputPath, filepath.Join(root, "data", "homepage.pl.txt")},
+		"Content": {readFile(t, outputPath), "Home"},
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		deletedPath, err := service.DeleteSingle(entry("homepage", 1, "Home", "locale", "pl"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, statErr := os.Stat(outputPath)
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Path":    {deletedPath, outputPath},
+			"Removed": {os.IsNotExist(statErr), true},
+		})
+	})
+
+	t.Run("DeleteMissing", func(t *testing.T) {
+		deletedPath, err := service.DeleteSingle(entry("homepage", 1, "Home", "locale", "pl"))
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Error": {err, nil},
+			"Path":  {deletedPath, ""},
+		})
+	})
+}
+
+// mediaPayload writes the media file in the media directory of the site, staging it first.
+type mediaPayload struct {
+	midas.Payload
+}
+
+func (p mediaPayload) Mirror(site midas.Site, stage func(path string) error) ([]string, error) {
+	path := filepath.Join(site.RootDir, site.MediaDir, "photo.png")
+	if err := stage(path); err != nil {
+		return nil, err
+	}
+
+	return []string{path}, os.WriteFile(path, []byte(fmt.Sprint(p.Entry()["Title"])), 0664)
+}
+
+func TestService_MirrorMedia(t *testing.T) {
+	root := t.TempDir()
+	service := newService(t, midas.Site{RootDir: root, MediaDir: "uploads"})
+
+	if err := os.MkdirAll(filepath.Join(root, "uploads"), 0775); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := service.MirrorMedia(mediaPayload{entry("media", 1, "old")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = service.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = service.MirrorMedia(mediaPayload{entry("media", 1, "new")}); err != nil {
+		t.Fatal(err)
+	}
+	if err = service.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+
+	testing_utils.AssertTable(t, map[string][]interface{}{
+		"Files":    {len(files), 1},
+		"Restored": {readFile(t, files[0]), "old"},
+	})
+}
+
+func TestService_Sync(t *testing.T) {
+	root := t.TempDir()
+	service := newService(t, midas.Site{
+		RootDir:         root,
+		Locales:         midas.LocaleSettings{Mapping: map[string]string{"pl-PL": "pl"}},
+		CollectionTypes: map[string]midas.ModelSettings{"post": {OutputDir: "posts"}},
+		SingleTypes:     map[string]midas.ModelSettings{"footer": {OutputDir: "data"}},
+	})
+
+	for _, payload := range []midas.Payload{entry("post", 1, "Kept"), entry("post", 2, "Stale")} {
+		if _, err := service.CreateEntry(payload); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := service.UpdateSingle(entry("footer", 1, "Footer", "locale", "pl-PL")); err != nil {
+		t.Fatal(err)
+	}
+	if err := service.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	untracked := filepath.Join(root, "posts", "handwritten.txt")
+	if err := os.WriteFile(untracked, []byte("old"), 0664); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := service.Sync([]midas.Payload{entry("post", 1, "Kept"), entry("post", 3, "Created")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	registry := snapshot(t, service)
+	_, untrackedErr := os.Stat(untracked)
+
+	testing_utils.AssertEquals(t, len(result.Removed), 2, "Removed")
+	testing_utils.AssertTable(t, map[string][]interface{}{
+		"Updated":        {result.Updated, 2},
+		"Removed entry":  {result.Removed[0], filepath.Join(root, "posts", "stale.txt")},
+		"Removed single": {result.Removed[1], filepath.Join(root, "data", "footer.pl-PL.txt")},
+		"Created":        {registry["post-3"], filepath.Join(root, "posts", "created.txt")},
+		"Untracked kept": {untrackedErr, nil},
+	})
+
+	t.Run("Rollback", func(t *testing.T) {
+		if err = service.Rollback(); err != nil {
+			t.Fatal(err)
+		}
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Entry restored":  {readFile(t, result.Removed[0]), "Stale"},
+			"Single restored": {readFile(t, result.Removed[1]), "Footer"},
+			"Registry":        {snapshot(t, service)["post-2"], result.Removed[0]},
+		})
+	})
+}
@@ -0,0 +1,65 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package contentful
+
+import "strings"
+
+// TopicHeader is the header of the webhook request holding the topic, i.e. ContentManagement.Entry.publish.
+const TopicHeader = "X-Contentful-Topic"
+
+type Event int64
+
+const (
+	Undefined Event = iota
+	Publish
+	Unpublish
+	Delete
+)
+
+var toAction = map[Event]string{
+	Undefined: "",
+	Publish:   "publish",
+	Unpublish: "unpublish",
+	Delete:    "delete",
+}
+
+var toString = map[Event]string{
+	Undefined: "",
+	Publish:   "Publish",
+	Unpublish: "Unpublish",
+	Delete:    "Delete",
+}
+
+var toId = map[string]Event{
+	"publish":   Publish,
+	"unpublish": Unpublish,
+	"delete":    Delete,
+}
+
+// ParseTopic returns the event of the webhook topic. Topics of other entities than entries (i.e. assets) and other
+// actions are Undefined.
+func ParseTopic(topic string) Event {
+	parts := strings.Split(topic, ".")
+	if len(parts) != 3 || parts[0] != "ContentManagement" || parts[1] != "Entry" {
+		return Undefined
+	}
+
+	return toId[parts[2]]
+}
+
+func (event Event) String() string {
+	return toString[event]
+}
+
+// Topic returns the webhook topic of the event.
+func (event Event) Topic() string {
+	if event == Undefined {
+		return ""
+	}
+
+	return "ContentManagement.Entry." + toAction[event]
+}
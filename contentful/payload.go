@@ -0,0 +1,205 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package contentful
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/kovansky/midas"
+	"sort"
+	"time"
+)
+
+var _ midas.Payload = (*Payload)(nil)
+
+// Payload is the payload of the Contentful entry webhook. Fields of the entry are maps of the locale codes to the
+// values; the entry of the payload holds the values in one locale (see Localize).
+type Payload struct {
+	event     Event
+	CreatedAt time.Time
+	Model     string
+	sys       map[string]interface{}
+	fields    map[string]map[string]interface{}
+	locale    string
+	metadata  map[string]interface{}
+	entry     map[string]interface{}
+}
+
+// ParsePayload parses the webhook payload of the topic (the X-Contentful-Topic header). The entry of the parsed
+// payload is not localized.
+func ParsePayload(topic string, json []byte) (*Payload, error) {
+	event := ParseTopic(topic)
+	if event == Undefined {
+		return nil, midas.Errorf(midas.ErrInvalid, "topic %s is not supported", topic)
+	}
+
+	payload := Payload{}
+	if err := payload.UnmarshalJSON(json); err != nil {
+		return nil, err
+	}
+
+	payload.event = event
+
+	contentType, _ := payload.sys["contentType"].(map[string]interface{})
+	contentTypeSys, _ := contentType["sys"].(map[string]interface{})
+	payload.Model, _ = contentTypeSys["id"].(string)
+	if payload.Model == "" {
+		return nil, midas.Errorf(midas.ErrInvalid, "entry has no content type")
+	}
+
+	// Deleted entries have deletedAt instead of updatedAt.
+	for _, key := range []string{"updatedAt", "deletedAt", "createdAt"} {
+		if value, ok := payload.sys[key].(string); ok {
+			if createdAt, err := time.Parse(time.RFC3339, value); err == nil {
+				payload.CreatedAt = createdAt
+				break
+			}
+		}
+	}
+
+	payload.createEntry()
+
+	return &payload, nil
+}
+
+// Locales returns the locale codes of the entry fields, sorted.
+func (p Payload) Locales() []string {
+	seen := make(map[string]bool)
+	var locales []string
+
+	for _, values := range p.fields {
+		for locale := range values {
+			if !seen[locale] {
+				seen[locale] = true
+				locales = append(locales, locale)
+			}
+		}
+	}
+
+	sort.Strings(locales)
+
+	return locales
+}
+
+// Localize returns the copy of the payload, with the entry holding the field values in the locale, and the locale in
+// the metadata. Fields without value in the locale are omitted. With empty locale, the entry is not localized: each
+// field holds the value of its first locale (sorted), which is meant for the spaces with a single locale.
+func (p Payload) Localize(locale string) *Payload {
+	localized := p
+	localized.locale = locale
+	localized.createEntry()
+
+	return &localized
+}
+
+func (p Payload) Event() string {
+	return p.event.String()
+}
+
+func (p Payload) Metadata() map[string]interface{} {
+	return p.metadata
+}
+
+// createEntry flattens the fields in the payload locale into the entry, along with the entry id and timestamps, and
+// creates the metadata.
+func (p *Payload) createEntry() {
+	entry := make(map[string]interface{}, len(p.fields)+4)
+
+	for name, values := range p.fields {
+		if p.locale != "" {
+			if value, ok := values[p.locale]; ok {
+				entry[name] = value
+			}
+			continue
+		}
+
+		locales := make([]string, 0, len(values))
+		for locale := range values {
+			locales = append(locales, locale)
+		}
+		sort.Strings(locales)
+
+		if len(locales) > 0 {
+			entry[name] = values[locales[0]]
+		}
+	}
+
+	for _, key := range []string{"id", "createdAt", "updatedAt", "publishedAt"} {
+		if value, ok := p.sys[key]; ok {
+			entry[key] = value
+		}
+	}
+	if p.locale != "" {
+		entry["locale"] = p.locale
+	}
+
+	p.entry = entry
+	p.createMetadataMap()
+}
+
+func (p *Payload) createMetadataMap() {
+	asMap := make(map[string]interface{})
+
+	asMap["event"] = p.event
+	asMap["createdAt"] = p.CreatedAt
+	asMap["model"] = p.Model
+	asMap["entryId"] = p.sys["id"]
+	asMap["revision"] = p.sys["revision"]
+
+	// Unpublished entries are removed from the site, so the published ones are never drafts.
+	asMap["published"] = p.event == Publish
+	asMap["draft"] = false
+
+	if p.locale != "" {
+		asMap["locale"] = p.locale
+		// Translations are the locales of the same entry.
+		asMap["translationKey"] = fmt.Sprintf("%s-%v", p.Model, p.sys["id"])
+	}
+
+	p.metadata = asMap
+}
+
+func (p Payload) Entry() map[string]interface{} {
+	return p.entry
+}
+
+func (p *Payload) SetEntry(entry map[string]interface{}) {
+	p.entry = entry
+}
+
+func (p Payload) Raw() interface{} {
+	return p
+}
+
+// MarshalJSON marshals the webhook with the entry of the payload (the fields in the payload locale) in place of the
+// fields in all locales, so the data files hold the localized and sanitized entry.
+func (p Payload) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Topic string                 `json:"topic"`
+		Sys   map[string]interface{} `json:"sys"`
+		Entry map[string]interface{} `json:"entry,omitempty"`
+	}{
+		p.event.Topic(), p.sys, p.entry,
+	})
+}
+
+func (p *Payload) UnmarshalJSON(bytes []byte) error {
+	var data struct {
+		Sys    map[string]interface{}            `json:"sys"`
+		Fields map[string]map[string]interface{} `json:"fields,omitempty"`
+	}
+	if err := json.Unmarshal(bytes, &data); err != nil {
+		return err
+	}
+
+	*p = Payload{
+		event:  p.event,
+		sys:    data.Sys,
+		fields: data.Fields,
+	}
+	return nil
+}
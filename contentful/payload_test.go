@@ -0,0 +1,148 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package contentful
+
+import (
+	"encoding/json"
+	"github.com/kovansky/midas"
+	"github.com/kovansky/midas/bluemonday"
+	"github.com/kovansky/midas/content"
+	"github.com/kovansky/midas/testing_utils"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func readSample(t *testing.T, name string) []byte {
+	data, err := os.ReadFile(filepath.Join("testdata", name+".json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return data
+}
+
+func TestParsePayload(t *testing.T) {
+	t.Run("Publish", func(t *testing.T) {
+		payload, err := ParsePayload("ContentManagement.Entry.publish", readSample(t, "publish"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		author, _ := payload.Entry()["author"].(map[string]interface{})
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Event":       {payload.Event(), Publish.String()},
+			"Model":       {payload.Metadata()["model"], "post"},
+			"Entry id":    {payload.Metadata()["entryId"], "5KsDBWseXY6QegucYAoacS"},
+			"Published":   {payload.Metadata()["published"], true},
+			"Unlocalized": {payload.Metadata()["locale"], nil},
+			"Title":       {payload.Entry()["title"], "Hallo Welt"},
+			"Id":          {payload.Entry()["id"], "5KsDBWseXY6QegucYAoacS"},
+			"Link":        {author["sys"] != nil, true},
+			"Created at":  {payload.CreatedAt.Format("2006-01-02"), "2026-03-04"},
+			"Locales":     {len(payload.Locales()), 2},
+		})
+	})
+
+	t.Run("Localize", func(t *testing.T) {
+		payload, err := ParsePayload("ContentManagement.Entry.publish", readSample(t, "publish"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		english, german := payload.Localize("en-US"), payload.Localize("de-DE")
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"English title":    {english.Entry()["title"], "Hello World"},
+			"English locale":   {english.Metadata()["locale"], "en-US"},
+			"Entry locale":     {english.Entry()["locale"], "en-US"},
+			"German title":     {german.Entry()["title"], "Hallo Welt"},
+			"German body":      {german.Entry()["body"], nil},
+			"Translation key":  {german.Metadata()["translationKey"], english.Metadata()["translationKey"]},
+			"Original payload": {payload.Metadata()["locale"], nil},
+		})
+	})
+
+	t.Run("RenderJSON", func(t *testing.T) {
+		midas.Sanitizer = bluemonday.NewSanitizerService()
+
+		payload, err := ParsePayload("ContentManagement.Entry.publish", readSample(t, "publish"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		localized := payload.Localize("de-DE")
+		localized.entry["body"] = "<p>Erster</p><script>alert(1)</script>"
+
+		rendered, err := content.RenderJSON(localized)
+
+		var data struct {
+			Entry  map[string]interface{} `json:"entry"`
+			Fields interface{}            `json:"fields"`
+		}
+		jsonErr := json.Unmarshal(rendered, &data)
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Error":      {err, nil},
+			"JSON error": {jsonErr, nil},
+			"Title":      {data.Entry["title"], "Hallo Welt"},
+			"Body":       {data.Entry["body"], "<p>Erster</p>"},
+			"No fields":  {data.Fields, nil},
+		})
+	})
+
+	t.Run("Unpublish", func(t *testing.T) {
+		payload, err := ParsePayload("ContentManagement.Entry.unpublish", readSample(t, "unpublish"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Event":     {payload.Event(), Unpublish.String()},
+			"Model":     {payload.Metadata()["model"], "post"},
+			"Published": {payload.Metadata()["published"], false},
+			"Locales":   {len(payload.Locales()), 0},
+		})
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		payload, err := ParsePayload("ContentManagement.Entry.delete", readSample(t, "delete"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testing_utils.AssertEquals(t, payload.Event(), Delete.String(), "Event")
+	})
+
+	t.Run("UnsupportedTopic", func(t *testing.T) {
+		_, err := ParsePayload("ContentManagement.Asset.publish", readSample(t, "publish"))
+		testing_utils.AssertEquals(t, midas.ErrorCode(err), midas.ErrInvalid, "Error code")
+	})
+}
+
+func TestParseTopic(t *testing.T) {
+	tests := []struct {
+		topic string
+		want  Event
+	}{
+		{"ContentManagement.Entry.publish", Publish},
+		{"ContentManagement.Entry.unpublish", Unpublish},
+		{"ContentManagement.Entry.delete", Delete},
+		{"ContentManagement.Entry.save", Undefined},
+		{"ContentManagement.Asset.publish", Undefined},
+		{"", Undefined},
+	}
+	for _, tt := range tests {
+		t.Run(tt.topic, func(t *testing.T) {
+			testing_utils.AssertEquals(t, ParseTopic(tt.topic), tt.want, "Event")
+			if tt.want != Undefined {
+				testing_utils.AssertEquals(t, tt.want.Topic(), tt.topic, "Topic")
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package contentful
+
+import (
+	"fmt"
+	"github.com/kovansky/midas"
+	"github.com/rs/zerolog"
+	"sort"
+	"strings"
+)
+
+var _ midas.Provider = (*Provider)(nil)
+
+// Provider maps the Contentful webhooks to the content changes of the sites.
+type Provider struct{}
+
+func NewProvider() *Provider {
+	return &Provider{}
+}
+
+// Changes returns the updates of the published entry (in all its locales on multilingual sites), or the removals of
+// the unpublished or deleted entry.
+func (p Provider) Changes(site midas.Site, siteService midas.SiteService, webhook midas.Webhook, _ zerolog.Logger) ([]midas.Change, error) {
+	payload, err := ParsePayload(webhook.Header.Get(TopicHeader), webhook.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	_, isSingle, err := site.AcceptModel(payload.Model)
+	if err != nil {
+		return nil, err
+	}
+
+	var action midas.Action
+	var payloads []midas.Payload
+
+	switch payload.event {
+	case Publish:
+		action = midas.ActionUpdate
+		payloads = localized(site, payload, payload.Locales())
+	case Unpublish, Delete:
+		// Unpublished entries are not delivered anymore, and their payloads have no fields to regenerate them.
+		action = midas.ActionDelete
+		if isSingle {
+			payloads = localized(site, payload, singleLocales(site.Locales))
+		} else if payloads, err = removed(site, siteService, payload); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, midas.Errorf(midas.ErrInvalid, "event %s is invalid", payload.Event())
+	}
+
+	return midas.NewChanges(action, payloads...), nil
+}
+
+// localized returns the payloads of the entry in the locales on multilingual sites, or the single unlocalized payload
+// otherwise.
+func localized(site midas.Site, payload *Payload, locales []string) []midas.Payload {
+	if !site.Locales.Enabled() {
+		return []midas.Payload{payload.Localize("")}
+	}
+
+	payloads := make([]midas.Payload, 0, len(locales))
+	for _, locale := range locales {
+		payloads = append(payloads, payload.Localize(locale))
+	}
+
+	return payloads
+}
+
+// removed returns the payloads of the collection entry locales present in the registry, as the payloads of removed
+// entries have no fields to tell their locales.
+func removed(site midas.Site, siteService midas.SiteService, payload *Payload) ([]midas.Payload, error) {
+	registry, err := siteService.GetRegistryService()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := registry.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	entryId := fmt.Sprintf("%v-%v", payload.Model, payload.Metadata()["entryId"])
+
+	var locales []string
+	for id := range entries {
+		if id == entryId && !site.Locales.Enabled() {
+			return []midas.Payload{payload.Localize("")}, nil
+		} else if strings.HasPrefix(id, entryId+"-") && site.Locales.Enabled() {
+			locales = append(locales, strings.TrimPrefix(id, entryId+"-"))
+		}
+	}
+	sort.Strings(locales)
+
+	return localized(site, payload, locales), nil
+}
+
+// singleLocales returns the locales of the single types on multilingual sites: the default locale and the mapped ones.
+func singleLocales(settings midas.LocaleSettings) []string {
+	locales := make([]string, 0, len(settings.Mapping)+1)
+	if settings.Default != "" {
+		locales = append(locales, settings.Default)
+	}
+
+	for locale := range settings.Mapping {
+		if locale != settings.Default {
+			locales = append(locales, locale)
+		}
+	}
+	sort.Strings(locales)
+
+	return locales
+}
@@ -0,0 +1,140 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package deploy
+
+import (
+	"context"
+	"github.com/kovansky/midas"
+	"github.com/rs/zerolog"
+	"strings"
+	"sync"
+)
+
+// Run executes all enabled deployments of the site, either one after another or in parallel, depending on the site's
+// deployment strategy, and returns the per-target results in the order of configuration.
+//
+// Failure of an optional deployment is only reported in its result. If any of the required deployments fails, an
+// ErrDeployment error is returned. In sequential mode deployments following a failed required one are skipped.
+func Run(site midas.Site, log zerolog.Logger) ([]midas.DeploymentResult, error) {
+	return run(site, log, false)
+}
+
+// Plan computes the changes of all enabled deployments of the site (dry run), without changing anything on the
+// targets. The plans are returned in the per-target results. Errors are handled the same way as in Run, except that
+// no deployment is skipped.
+func Plan(site midas.Site, log zerolog.Logger) ([]midas.DeploymentResult, error) {
+	return run(site, log, true)
+}
+
+func run(site midas.Site, log zerolog.Logger, dryRun bool) ([]midas.DeploymentResult, error) {
+	deployments := site.EnabledDeployments()
+	results := make([]midas.DeploymentResult, len(deployments))
+
+	switch site.DeploymentStrategy {
+	case "", midas.DeploymentSequential:
+		var failed bool
+
+		for i, settings := range deployments {
+			if failed {
+				results[i] = newResult(settings, midas.DeploymentSkipped, nil)
+				continue
+			}
+
+			results[i] = runOne(site, settings, log, dryRun)
+			failed = !dryRun && results[i].Required && results[i].Status == midas.DeploymentFailed
+		}
+	case midas.DeploymentParallel:
+		var wg sync.WaitGroup
+
+		for i, settings := range deployments {
+			wg.Add(1)
+
+			go func(i int, settings midas.DeploymentSettings) {
+				defer wg.Done()
+				results[i] = runOne(site, settings, log, dryRun)
+			}(i, settings)
+		}
+
+		wg.Wait()
+	default:
+		return nil, midas.Errorf(midas.ErrSiteConfig, "deployment strategy %s is not supported", site.DeploymentStrategy)
+	}
+
+	var failed []string
+	for _, result := range results {
+		if result.Required && result.Status == midas.DeploymentFailed {
+			failed = append(failed, result.Name)
+		}
+	}
+
+	if len(failed) > 0 {
+		return results, midas.Errorf(midas.ErrDeployment, "required deployments failed: %s", strings.Join(failed, ", "))
+	}
+
+	return results, nil
+}
+
+// runOne creates and executes (or plans, in dry run) a single deployment.
+func runOne(site midas.Site, settings midas.DeploymentSettings, log zerolog.Logger, dryRun bool) midas.DeploymentResult {
+	dpl, ok := midas.DeploymentTargets[settings.Target]
+	if !ok {
+		return newResult(settings, midas.DeploymentFailed,
+			midas.Errorf(midas.ErrUnaccepted, "deployment target %s is not accepted", settings.Target))
+	}
+
+	deploymentService, err := dpl(site, settings, settings.Draft)
+	if err != nil {
+		return newResult(settings, midas.DeploymentFailed,
+			midas.Errorf(midas.ErrInternal, "could not create deployment %s: %s", settings.Target, err))
+	}
+
+	if dryRun {
+		log.Debug().Msgf("Planning deployment of %s to %s (%s)", site.SiteName, settings.Target, settings.Name)
+
+		plan, err := deploymentService.Plan()
+		if err != nil {
+			log.Error().Err(err).Msgf("Planning deployment %s failed", settings.Name)
+
+			return newResult(settings, midas.DeploymentFailed, err)
+		}
+
+		result := newResult(settings, midas.DeploymentOk, nil)
+		result.Plan = plan
+
+		return result
+	}
+
+	log.Debug().Msgf("Deploying %s to %s (%s)", site.SiteName, settings.Target, settings.Name)
+	if err = deploymentService.Deploy(); err != nil {
+		log.Error().Err(err).Msgf("Deployment %s failed", settings.Name)
+
+		return newResult(settings, midas.DeploymentFailed, err)
+	}
+
+	return newResult(settings, midas.DeploymentOk, nil)
+}
+
+func newResult(settings midas.DeploymentSettings, status string, err error) midas.DeploymentResult {
+	result := midas.DeploymentResult{
+		Name:     settings.Name,
+		Target:   settings.Target,
+		Draft:    settings.Draft,
+		Required: settings.IsRequired(),
+		Status:   status,
+	}
+
+	if err != nil {
+		// Details of the internal errors are not displayed to the enduser, so they need to be reported.
+		if midas.ErrorCode(err) == midas.ErrInternal {
+			midas.ReportError(context.Background(), err)
+		}
+
+		result.Error = midas.ErrorMessage(err)
+	}
+
+	return result
+}
@@ -0,0 +1,229 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package deploy
+
+import (
+	"errors"
+	"github.com/kovansky/midas"
+	"github.com/kovansky/midas/mock"
+	"github.com/kovansky/midas/testing_utils"
+	"github.com/rs/zerolog"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// deployed collects names of the executed mock deployments.
+type deployed struct {
+	mu    sync.Mutex
+	names []string
+}
+
+func setUpTargets(t *testing.T, failing ...string) *deployed {
+	t.Helper()
+
+	calls := &deployed{}
+	previous := midas.DeploymentTargets
+	t.Cleanup(func() { midas.DeploymentTargets = previous })
+
+	midas.DeploymentTargets = map[string]func(site midas.Site, settings midas.DeploymentSettings, isDraft bool) (midas.Deployment, error){
+		"mock": func(site midas.Site, settings midas.DeploymentSettings, isDraft bool) (midas.Deployment, error) {
+			deployment := mock.NewDeployment(site, settings, isDraft)
+			deployment.DeployFn = func() error {
+				calls.mu.Lock()
+				calls.names = append(calls.names, settings.Name)
+				calls.mu.Unlock()
+
+				for _, name := range failing {
+					if name == settings.Name {
+						return midas.Errorf(midas.ErrInvalid, "%s failed", name)
+					}
+				}
+
+				return nil
+			}
+			deployment.PlanFn = func() (*midas.DeploymentPlan, error) {
+				for _, name := range failing {
+					if name == settings.Name {
+						return nil, midas.Errorf(midas.ErrInvalid, "%s failed", name)
+					}
+				}
+
+				return &midas.DeploymentPlan{Upload: []string{settings.Name + "/index.html"}}, nil
+			}
+
+			return deployment, nil
+		},
+	}
+
+	return calls
+}
+
+func optional() *bool {
+	required := false
+	return &required
+}
+
+func TestSite_EnabledDeployments(t *testing.T) {
+	site := midas.Site{
+		Deployment:       midas.DeploymentSettings{Enabled: true, Target: "mock"},
+		DraftsDeployment: midas.DeploymentSettings{Enabled: true, Target: "mock"},
+		Deployments: []midas.DeploymentSettings{
+			{Enabled: true, Target: "mock", Name: "s3"},
+			{Enabled: false, Target: "mock", Name: "disabled"},
+			{Enabled: true, Target: "mock", Draft: true},
+		},
+	}
+
+	deployments := site.EnabledDeployments()
+	var names []string
+	for _, deployment := range deployments {
+		names = append(names, deployment.Name)
+	}
+
+	testing_utils.AssertTable(t, map[string][]interface{}{
+		"Deployments count":   {len(deployments), 4},
+		"Names":               {strings.Join(names, ","), "deployment,draftsDeployment,s3,deployments[2]"},
+		"Legacy draft":        {deployments[1].Draft, true},
+		"Listed draft":        {deployments[3].Draft, true},
+		"Required by default": {deployments[2].IsRequired(), true},
+	})
+}
+
+func TestRun(t *testing.T) {
+	t.Run("NoDeployments", func(t *testing.T) {
+		calls := setUpTargets(t)
+
+		results, err := Run(midas.Site{}, zerolog.Nop())
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Error":         {err, nil},
+			"Results count": {len(results), 0},
+			"Calls":         {len(calls.names), 0},
+		})
+	})
+
+	for _, strategy := range []string{midas.DeploymentSequential, midas.DeploymentParallel} {
+		t.Run(strategy, func(t *testing.T) {
+			t.Run("AllOk", func(t *testing.T) {
+				calls := setUpTargets(t)
+				site := midas.Site{
+					DeploymentStrategy: strategy,
+					Deployments: []midas.DeploymentSettings{
+						{Enabled: true, Target: "mock", Name: "s3"},
+						{Enabled: true, Target: "mock", Name: "mirror"},
+					},
+				}
+
+				results, err := Run(site, zerolog.Nop())
+
+				testing_utils.AssertTable(t, map[string][]interface{}{
+					"Error":         {err, nil},
+					"Calls":         {len(calls.names), 2},
+					"First status":  {results[0].Status, midas.DeploymentOk},
+					"Second status": {results[1].Status, midas.DeploymentOk},
+					"Result order":  {results[1].Name, "mirror"},
+				})
+			})
+
+			t.Run("OptionalFailed", func(t *testing.T) {
+				setUpTargets(t, "mirror")
+				site := midas.Site{
+					DeploymentStrategy: strategy,
+					Deployments: []midas.DeploymentSettings{
+						{Enabled: true, Target: "mock", Name: "s3"},
+						{Enabled: true, Target: "mock", Name: "mirror", Required: optional()},
+					},
+				}
+
+				results, err := Run(site, zerolog.Nop())
+
+				testing_utils.AssertTable(t, map[string][]interface{}{
+					"Error":           {err, nil},
+					"Required status": {results[0].Status, midas.DeploymentOk},
+					"Optional status": {results[1].Status, midas.DeploymentFailed},
+					"Optional error":  {results[1].Error, "mirror failed"},
+				})
+			})
+
+			t.Run("RequiredFailed", func(t *testing.T) {
+				setUpTargets(t, "s3")
+				site := midas.Site{
+					DeploymentStrategy: strategy,
+					Deployments: []midas.DeploymentSettings{
+						{Enabled: true, Target: "mock", Name: "s3"},
+						{Enabled: true, Target: "mock", Name: "mirror", Required: optional()},
+					},
+				}
+
+				results, err := Run(site, zerolog.Nop())
+
+				secondStatus := midas.DeploymentOk
+				if strategy == midas.DeploymentSequential {
+					secondStatus = midas.DeploymentSkipped
+				}
+
+				testing_utils.AssertTable(t, map[string][]interface{}{
+					"Error code":    {midas.ErrorCode(err), midas.ErrDeployment},
+					"Error message": {midas.ErrorMessage(err), "required deployments failed: s3"},
+					"First status":  {results[0].Status, midas.DeploymentFailed},
+					"Second status": {results[1].Status, secondStatus},
+				})
+			})
+		})
+	}
+
+	t.Run("UnknownTarget", func(t *testing.T) {
+		setUpTargets(t)
+		site := midas.Site{
+			Deployments: []midas.DeploymentSettings{{Enabled: true, Target: "unknown", Name: "s3"}},
+		}
+
+		results, err := Run(site, zerolog.Nop())
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Error code":   {midas.ErrorCode(err), midas.ErrDeployment},
+			"Status":       {results[0].Status, midas.DeploymentFailed},
+			"Result error": {results[0].Error, "deployment target unknown is not accepted"},
+		})
+	})
+
+	t.Run("UnknownStrategy", func(t *testing.T) {
+		setUpTargets(t)
+
+		_, err := Run(midas.Site{DeploymentStrategy: "random"}, zerolog.Nop())
+
+		var midasErr *midas.Error
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Error type": {errors.As(err, &midasErr), true},
+			"Error code": {midas.ErrorCode(err), midas.ErrSiteConfig},
+		})
+	})
+}
+
+func TestPlan(t *testing.T) {
+	calls := setUpTargets(t, "s3")
+	site := midas.Site{
+		Deployments: []midas.DeploymentSettings{
+			{Enabled: true, Target: "mock", Name: "s3"},
+			{Enabled: true, Target: "mock", Name: "mirror"},
+		},
+	}
+
+	results, err := Plan(site, zerolog.Nop())
+
+	testing_utils.AssertTable(t, map[string][]interface{}{
+		"Error code":      {midas.ErrorCode(err), midas.ErrDeployment},
+		"Deploy calls":    {len(calls.names), 0},
+		"Failed status":   {results[0].Status, midas.DeploymentFailed},
+		"Failed plan":     {results[0].Plan == nil, true},
+		"Not skipped":     {results[1].Status, midas.DeploymentOk},
+		"Planned upload":  {results[1].Plan.Upload[0], "mirror/index.html"},
+		"Planned uploads": {len(results[1].Plan.Upload), 1},
+		"Planned deletes": {len(results[1].Plan.Delete), 0},
+	})
+}
@@ -8,13 +8,56 @@ package midas
 
 type Deployment interface {
 	Deploy() error
+	// Plan computes the changes Deploy would make, without changing anything on the target.
+	Plan() (*DeploymentPlan, error)
+}
+
+// DeploymentPlan lists the changes of a deployment. Files are identified by their paths (or keys) on the target.
+type DeploymentPlan struct {
+	Upload     []string `json:"upload"`
+	Update     []string `json:"update"`
+	Delete     []string `json:"delete"`
+	Invalidate []string `json:"invalidate,omitempty"` // CDN paths to invalidate
 }
 
 type DeploymentSettings struct {
-	Enabled bool                   `json:"enabled,default=false"`
-	Target  string                 `json:"target"` // Can be: AWS, SFTP
-	AWS     AWSDeploymentSettigs   `json:"aws,omitempty"`
-	SFTP    SFTPDeploymentSettings `json:"sftp,omitempty"`
+	Name     string                   `json:"name,omitempty"`
+	Enabled  bool                     `json:"enabled,default=false"`
+	Required *bool                    `json:"required,omitempty"` // Default: true
+	Draft    bool                     `json:"draft,omitempty"`    // Used only in Site.Deployments
+	Target   string                   `json:"target"`             // Can be: AWS, SFTP, WebDAV, FTP
+	AWS      AWSDeploymentSettigs     `json:"aws,omitempty"`
+	SFTP     SFTPDeploymentSettings   `json:"sftp,omitempty"`
+	WebDAV   WebDAVDeploymentSettings `json:"webdav,omitempty"`
+	FTP      FTPDeploymentSettings    `json:"ftp,omitempty"`
+}
+
+// IsRequired tells if failure of this deployment should fail the whole request.
+func (d DeploymentSettings) IsRequired() bool {
+	return d.Required == nil || *d.Required
+}
+
+const (
+	DeploymentSequential = "sequential"
+	DeploymentParallel   = "parallel"
+)
+
+const (
+	DeploymentOk      = "ok"
+	DeploymentFailed  = "failed"
+	DeploymentSkipped = "skipped"
+)
+
+// DeploymentResult describes the outcome of a single deployment target.
+type DeploymentResult struct {
+	Name     string `json:"name"`
+	Target   string `json:"target"`
+	Draft    bool   `json:"draft"`
+	Required bool   `json:"required"`
+	Status   string `json:"status"` // Can be: ok, failed, skipped
+	Error    string `json:"error,omitempty"`
+
+	Plan *DeploymentPlan `json:"plan,omitempty"` // Only in dry run
 }
 
 type AWSDeploymentSettigs struct {
@@ -36,3 +79,21 @@ type SFTPDeploymentSettings struct {
 	KeyPassphrase string `json:"keyPassphrase,omitempty"`
 	Path          string `json:"path"`
 }
+
+type WebDAVDeploymentSettings struct {
+	Url      string `json:"url"`
+	User     string `json:"user"`
+	Method   string `json:"method"` // Can be: none, basic, digest
+	Password string `json:"password,omitempty"`
+	Path     string `json:"path"`
+}
+
+type FTPDeploymentSettings struct {
+	Host     string `json:"host"`
+	Port     *int   `json:"port"`
+	User     string `json:"user"`
+	Password string `json:"password,omitempty"`
+	TLS      string `json:"tls"`              // Can be: none, explicit, implicit
+	CACert   string `json:"caCert,omitempty"` // PEM file with certificate authorities to trust, besides the system ones
+	Path     string `json:"path"`
+}
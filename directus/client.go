@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package directus
+
+import (
+	"encoding/json"
+	"github.com/kovansky/midas"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Client fetches the items from the Directus REST API.
+type Client struct {
+	baseUrl *url.URL
+	token   string
+	client  *http.Client
+}
+
+func NewClient(settings midas.DirectusSettings) (*Client, error) {
+	if settings.Url == "" {
+		return nil, midas.Errorf(midas.ErrSiteConfig, "directus url is not set")
+	}
+
+	baseUrl, err := url.Parse(strings.TrimSuffix(settings.Url, "/"))
+	if err != nil || baseUrl.Scheme == "" || baseUrl.Host == "" {
+		return nil, midas.Errorf(midas.ErrSiteConfig, "directus url %s is invalid", settings.Url)
+	}
+
+	return &Client{
+		baseUrl: baseUrl,
+		token:   settings.Token,
+		client:  &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// FetchItem fetches the item of the collection, with the related items one level deep. Items of singletons are
+// fetched without the key.
+func (c *Client) FetchItem(collection, key string, isSingleton bool) (map[string]interface{}, error) {
+	endpoint := *c.baseUrl
+	endpoint.Path += "/items/" + url.PathEscape(collection)
+	if !isSingleton {
+		endpoint.Path += "/" + url.PathEscape(key)
+	}
+	endpoint.RawQuery = url.Values{"fields": {"*.*"}}.Encode()
+
+	request, err := http.NewRequest(http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set("Accept", "application/json")
+	if c.token != "" {
+		request.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	response, err := c.client.Do(request)
+	if err != nil {
+		return nil, midas.Errorf(midas.ErrInternal, "could not fetch %s item %s from directus: %v", collection, key, err)
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	switch response.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound, http.StatusForbidden:
+		// Directus responds with forbidden to the requests of items which don't exist.
+		return nil, midas.Errorf(midas.ErrNotFound, "%s item %s not found in directus", collection, key)
+	default:
+		return nil, midas.Errorf(midas.ErrInternal, "directus responded to %s item %s with %s", collection, key, response.Status)
+	}
+
+	var body struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err = json.NewDecoder(response.Body).Decode(&body); err != nil {
+		return nil, midas.Errorf(midas.ErrInternal, "directus response malformed: %v", err)
+	}
+
+	if body.Data == nil {
+		return nil, midas.Errorf(midas.ErrNotFound, "%s item %s not found in directus", collection, key)
+	}
+
+	return body.Data, nil
+}
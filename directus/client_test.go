@@ -0,0 +1,65 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package directus
+
+import (
+	"fmt"
+	"github.com/kovansky/midas"
+	"github.com/kovansky/midas/testing_utils"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_FetchItem(t *testing.T) {
+	var requested *http.Request
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r
+
+		switch r.URL.Path {
+		case "/items/posts/5":
+			_, _ = fmt.Fprint(w, `{"data": {"id": 5, "title": "Hello", "author": {"id": 3, "name": "Jane"}}}`)
+		case "/items/settings":
+			_, _ = fmt.Fprint(w, `{"data": {"id": 1, "name": "Site"}}`)
+		default:
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(midas.DirectusSettings{Url: server.URL + "/", Token: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item, err := client.FetchItem("posts", "5", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	author, _ := item["author"].(map[string]interface{})
+
+	testing_utils.AssertTable(t, map[string][]interface{}{
+		"Authorization": {requested.Header.Get("Authorization"), "Bearer secret"},
+		"Fields":        {requested.URL.Query().Get("fields"), "*.*"},
+		"Title":         {item["title"], "Hello"},
+		"Author":        {author["name"], "Jane"},
+	})
+
+	singleton, err := client.FetchItem("settings", "", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testing_utils.AssertEquals(t, singleton["name"], "Site", "Singleton")
+
+	_, err = client.FetchItem("posts", "7", false)
+	testing_utils.AssertEquals(t, midas.ErrorCode(err), midas.ErrNotFound, "Missing item")
+
+	_, err = NewClient(midas.DirectusSettings{})
+	testing_utils.AssertEquals(t, midas.ErrorCode(err), midas.ErrSiteConfig, "No url")
+}
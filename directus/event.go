@@ -0,0 +1,75 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package directus
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+type Event int64
+
+const (
+	Undefined Event = iota
+	Create
+	Update
+	Delete
+)
+
+var toJson = map[Event]string{
+	Undefined: "",
+	Create:    "items.create",
+	Update:    "items.update",
+	Delete:    "items.delete",
+}
+
+var toString = map[Event]string{
+	Undefined: "",
+	Create:    "Create",
+	Update:    "Update",
+	Delete:    "Delete",
+}
+
+var toId = map[string]Event{
+	"items.create": Create,
+	"items.update": Update,
+	"items.delete": Delete,
+}
+
+func (event Event) String() string {
+	return toString[event]
+}
+
+func (event Event) MarshalJSON() ([]byte, error) {
+	buffer := bytes.NewBufferString(`"`)
+	buffer.WriteString(toJson[event])
+	buffer.WriteString(`"`)
+	return buffer.Bytes(), nil
+}
+
+// UnmarshalJSON parses the event of the webhook (items.create) or the flow trigger, prefixed with the collection
+// (posts.items.create).
+func (event *Event) UnmarshalJSON(bytes []byte) error {
+	var str string
+	err := json.Unmarshal(bytes, &str)
+
+	if err != nil {
+		return err
+	}
+
+	if parts := strings.Split(str, "."); len(parts) > 2 {
+		str = strings.Join(parts[len(parts)-2:], ".")
+	}
+
+	var ok bool
+
+	if *event, ok = toId[str]; !ok {
+		*event = Undefined
+	}
+	return nil
+}
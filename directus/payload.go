@@ -0,0 +1,173 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package directus
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/kovansky/midas"
+	"time"
+)
+
+var _ midas.Payload = (*Payload)(nil)
+
+// Payload is the payload of the Directus items event, sent by the webhook or the flow with event hook trigger. One
+// event concerns one or more items (keys); the entry of the payload is the item with the key (see Item).
+type Payload struct {
+	event      Event
+	CreatedAt  time.Time
+	Collection string
+	keys       []string
+	// item holds the fields sent with create and update events. Update events contain only the changed fields.
+	item     map[string]interface{}
+	key      string
+	metadata map[string]interface{}
+	entry    map[string]interface{}
+}
+
+// ParsePayload parses the payload of the items event.
+func ParsePayload(json []byte) (*Payload, error) {
+	payload := Payload{}
+	if err := payload.UnmarshalJSON(json); err != nil {
+		return nil, err
+	}
+
+	if payload.event == Undefined {
+		return nil, midas.Errorf(midas.ErrInvalid, "event is not supported")
+	}
+
+	if payload.Collection == "" {
+		return nil, midas.Errorf(midas.ErrInvalid, "payload has no collection")
+	}
+
+	// Directus doesn't send the time of the event.
+	payload.CreatedAt = time.Now()
+
+	if len(payload.keys) > 0 {
+		payload.key = payload.keys[0]
+	}
+	payload.entry = payload.item
+	payload.createMetadataMap()
+
+	return &payload, nil
+}
+
+// Keys returns the keys of the items concerned by the event.
+func (p Payload) Keys() []string {
+	return p.keys
+}
+
+// Item returns the copy of the payload concerning the item with the key. Entry of the payload is the item, with the
+// key as its id. Nil item means the fields sent with the event.
+func (p Payload) Item(key string, item map[string]interface{}) *Payload {
+	if item == nil {
+		item = p.item
+	}
+
+	entry := make(map[string]interface{}, len(item)+1)
+	for field, value := range item {
+		entry[field] = value
+	}
+	if _, ok := entry["id"]; !ok {
+		entry["id"] = key
+	}
+
+	itemPayload := p
+	itemPayload.key = key
+	itemPayload.entry = entry
+	itemPayload.createMetadataMap()
+
+	return &itemPayload
+}
+
+func (p Payload) Event() string {
+	return p.event.String()
+}
+
+func (p Payload) Metadata() map[string]interface{} {
+	return p.metadata
+}
+
+func (p *Payload) createMetadataMap() {
+	asMap := make(map[string]interface{})
+
+	asMap["event"] = p.event
+	asMap["createdAt"] = p.CreatedAt
+	asMap["model"] = p.Collection
+	asMap["entryId"] = p.key
+
+	// Collections with the status field (i.e. created with the content versioning preset) have drafts.
+	asMap["published"] = false
+	if status, ok := p.entry["status"].(string); ok {
+		asMap["published"] = status == "published"
+		asMap["draft"] = status != "published"
+	}
+
+	p.metadata = asMap
+}
+
+func (p Payload) Entry() map[string]interface{} {
+	return p.entry
+}
+
+func (p *Payload) SetEntry(entry map[string]interface{}) {
+	p.entry = entry
+	p.createMetadataMap()
+}
+
+func (p Payload) Raw() interface{} {
+	return p
+}
+
+// MarshalJSON marshals the event with the entry of the payload (the whole fetched item, if it was fetched) in place of
+// the changed fields, so the data files hold the sanitized entry.
+func (p Payload) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Event      Event                  `json:"event"`
+		Collection string                 `json:"collection"`
+		Keys       []string               `json:"keys"`
+		Payload    map[string]interface{} `json:"payload,omitempty"`
+	}{
+		p.event, p.Collection, p.keys, p.entry,
+	})
+}
+
+func (p *Payload) UnmarshalJSON(bytes []byte) error {
+	var data struct {
+		Event      Event           `json:"event"`
+		Collection string          `json:"collection"`
+		Key        interface{}     `json:"key"`
+		Keys       []interface{}   `json:"keys"`
+		Payload    json.RawMessage `json:"payload"`
+	}
+	if err := json.Unmarshal(bytes, &data); err != nil {
+		return err
+	}
+
+	newPayload := Payload{
+		event:      data.Event,
+		Collection: data.Collection,
+	}
+
+	// Create events have the key of the created item, others have the keys of all changed items.
+	if data.Key != nil {
+		data.Keys = append(data.Keys, data.Key)
+	}
+	for _, key := range data.Keys {
+		newPayload.keys = append(newPayload.keys, fmt.Sprint(key))
+	}
+
+	// Delete events have the keys as the payload.
+	if data.Event != Delete && len(data.Payload) > 0 {
+		if err := json.Unmarshal(data.Payload, &newPayload.item); err != nil {
+			return err
+		}
+	}
+
+	*p = newPayload
+	return nil
+}
@@ -0,0 +1,110 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package directus
+
+import (
+	"encoding/json"
+	"github.com/kovansky/midas"
+	"github.com/kovansky/midas/bluemonday"
+	"github.com/kovansky/midas/content"
+	"github.com/kovansky/midas/testing_utils"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func readSample(t *testing.T, name string) []byte {
+	data, err := os.ReadFile(filepath.Join("testdata", name+".json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return data
+}
+
+func TestParsePayload(t *testing.T) {
+	t.Run("Create", func(t *testing.T) {
+		payload, err := ParsePayload(readSample(t, "create"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Event":     {payload.Event(), Create.String()},
+			"Model":     {payload.Metadata()["model"], "posts"},
+			"Entry id":  {payload.Metadata()["entryId"], "5"},
+			"Keys":      {len(payload.Keys()), 1},
+			"Published": {payload.Metadata()["published"], true},
+			"Draft":     {payload.Metadata()["draft"], false},
+			"Title":     {payload.Entry()["title"], "Hello World"},
+		})
+	})
+
+	t.Run("UpdateFlow", func(t *testing.T) {
+		payload, err := ParsePayload(readSample(t, "update"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		item := payload.Item("6", map[string]interface{}{"id": float64(6), "title": "Fetched", "status": "draft"})
+		sent := payload.Item("5", nil)
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Event":        {payload.Event(), Update.String()},
+			"Keys":         {len(payload.Keys()), 2},
+			"Item id":      {item.Metadata()["entryId"], "6"},
+			"Item title":   {item.Entry()["title"], "Fetched"},
+			"Item draft":   {item.Metadata()["draft"], true},
+			"Sent title":   {sent.Entry()["title"], "Hello Directus"},
+			"Sent id":      {sent.Entry()["id"], "5"},
+			"No published": {sent.Metadata()["published"], false},
+		})
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		payload, err := ParsePayload(readSample(t, "delete"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Event": {payload.Event(), Delete.String()},
+			"Keys":  {payload.Keys()[0], "5"},
+			"Entry": {len(payload.Entry()), 0},
+		})
+	})
+
+	t.Run("RenderJSON", func(t *testing.T) {
+		midas.Sanitizer = bluemonday.NewSanitizerService()
+
+		payload, err := ParsePayload(readSample(t, "update"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		item := payload.Item("6", map[string]interface{}{"id": float64(6), "title": "Fetched<script>alert(1)</script>", "body": "Body"})
+
+		rendered, err := content.RenderJSON(item)
+
+		var data struct {
+			Payload map[string]interface{} `json:"payload"`
+		}
+		jsonErr := json.Unmarshal(rendered, &data)
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Error":      {err, nil},
+			"JSON error": {jsonErr, nil},
+			"Title":      {data.Payload["title"], "Fetched"},
+			"Body":       {data.Payload["body"], "Body"},
+		})
+	})
+
+	t.Run("UnsupportedEvent", func(t *testing.T) {
+		_, err := ParsePayload([]byte(`{"event": "users.create", "collection": "directus_users", "key": 1}`))
+		testing_utils.AssertEquals(t, midas.ErrorCode(err), midas.ErrInvalid, "Error code")
+	})
+}
@@ -0,0 +1,112 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package directus
+
+import (
+	"github.com/kovansky/midas"
+	"github.com/rs/zerolog"
+)
+
+var _ midas.Provider = (*Provider)(nil)
+
+// Provider maps the Directus items events to the content changes of the sites.
+type Provider struct{}
+
+func NewProvider() *Provider {
+	return &Provider{}
+}
+
+// Changes returns the changes of all items of the event. Update events contain only the changed fields, so the
+// updated items are fetched from the REST API, which requires the directus url of the site.
+func (p Provider) Changes(site midas.Site, _ midas.SiteService, webhook midas.Webhook, log zerolog.Logger) ([]midas.Change, error) {
+	payload, err := ParsePayload(webhook.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	_, isSingle, err := site.AcceptModel(payload.Collection)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().Fields(map[string]interface{}{
+		"model": payload.Collection,
+		"keys":  payload.Keys(),
+	}).Msg("Directus items")
+
+	var action midas.Action
+	var payloads []midas.Payload
+
+	switch payload.event {
+	case Create:
+		action = midas.ActionCreate
+		payloads, err = items(site, payload, isSingle)
+	case Update:
+		action = midas.ActionUpdate
+		if site.Directus.Url == "" {
+			return nil, midas.Errorf(midas.ErrSiteConfig, "directus url is required to handle the updates")
+		}
+
+		payloads, err = items(site, payload, isSingle)
+	case Delete:
+		action = midas.ActionDelete
+		for _, key := range keys(payload, isSingle) {
+			payloads = append(payloads, payload.Item(key, nil))
+		}
+	default:
+		return nil, midas.Errorf(midas.ErrInvalid, "event %s is invalid", payload.Event())
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return midas.NewChanges(action, payloads...), nil
+}
+
+// items returns the payloads of the items of the event. With the directus settings, the whole items are fetched from
+// the REST API (with the related items), otherwise the fields sent with the event are used.
+func items(site midas.Site, payload *Payload, isSingle bool) ([]midas.Payload, error) {
+	itemKeys := keys(payload, isSingle)
+	payloads := make([]midas.Payload, 0, len(itemKeys))
+
+	if site.Directus.Url == "" {
+		for _, key := range itemKeys {
+			payloads = append(payloads, payload.Item(key, nil))
+		}
+
+		return payloads, nil
+	}
+
+	client, err := NewClient(site.Directus)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range itemKeys {
+		item, err := client.FetchItem(payload.Collection, key, isSingle)
+		if midas.ErrorCode(err) == midas.ErrNotFound {
+			// The item was removed in the meantime, its own event handles it.
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		payloads = append(payloads, payload.Item(key, item))
+	}
+
+	return payloads, nil
+}
+
+// keys returns the keys of the items of the event. Singletons have one item, even if the event has no key.
+func keys(payload *Payload, isSingle bool) []string {
+	if keys := payload.Keys(); len(keys) > 0 || !isSingle {
+		return keys
+	}
+
+	return []string{""}
+}
@@ -0,0 +1,137 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package eleventy
+
+import (
+	"context"
+	"github.com/kovansky/midas"
+	"github.com/kovansky/midas/concurrent"
+	"github.com/kovansky/midas/content"
+	"github.com/rs/zerolog"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+var _ midas.SiteService = (*SiteService)(nil)
+
+// defaultCommand runs Eleventy installed in the site (or downloaded on the first run).
+var defaultCommand = []string{"npx", "@11ty/eleventy"}
+
+type SiteService struct {
+	content.Service
+}
+
+func NewSiteService(config midas.Site) (midas.SiteService, error) {
+	service, err := content.NewService(config, Layout{})
+	if err != nil {
+		return nil, err
+	}
+
+	return SiteService{service}, nil
+}
+
+// BuildSite builds the site with Eleventy, followed by the drafts build if enabled. Eleventy has no build cache, so
+// useCache is ignored.
+func (s SiteService) BuildSite(_ bool, _ zerolog.Logger) error {
+	err := concurrent.Run(s.Site, "eleventy", func(ctx context.Context) *exec.Cmd {
+		return s.command(ctx, false)
+	})
+	if err != nil {
+		return err
+	}
+
+	if s.Site.BuildDrafts {
+		return s.BuildDrafts()
+	}
+
+	return nil
+}
+
+func (s SiteService) BuildDrafts() error {
+	out, err := s.command(context.Background(), true).CombinedOutput()
+	if err != nil {
+		return midas.Errorf(midas.ErrDraftsBuild, "eleventy draft build errored: %s\ncommand output: %s", err, out)
+	}
+
+	return nil
+}
+
+// command returns the Eleventy build command, writing to the output dir of the build. Eleventy has no notion of
+// drafts, so the drafts build gets the draft environment in ELEVENTY_ENV, for the site config to include the entries
+// with the draft flag.
+func (s SiteService) command(ctx context.Context, isDraft bool) *exec.Cmd {
+	command := s.Site.Eleventy.Command
+	if len(command) == 0 {
+		command = defaultCommand
+	}
+
+	output := s.Site.BuildOutput(isDraft)
+
+	arg := append(append([]string{}, command[1:]...), "--output="+output.Dir)
+	if s.Site.Eleventy.Input != "" {
+		arg = append(arg, "--input="+s.Site.Eleventy.Input)
+	}
+
+	cmd := exec.CommandContext(ctx, command[0], arg...)
+	cmd.Dir = s.Site.RootDir
+
+	if output.Drafts {
+		cmd.Env = append(os.Environ(), "ELEVENTY_ENV="+output.Environment)
+	}
+
+	return cmd
+}
+
+// Layout writes the entries of the collection types to their output dirs, as markdown or JSON data files, and the
+// single types as JSON files (i.e. global data files in the _data dir).
+type Layout struct{}
+
+func (l Layout) EntryPath(modelName string, model *midas.ModelSettings, payload midas.Payload) (string, string, string, error) {
+	var extension string
+
+	switch model.Output {
+	case "", midas.OutputMarkdown:
+		extension = ".md"
+	case midas.OutputJSON:
+		extension = ".json"
+	default:
+		return "", "", "", midas.Errorf(midas.ErrSiteConfig, "output %s of model %s is not supported", model.Output, modelName)
+	}
+
+	filename, err := content.EntryFilename(modelName, model, payload)
+
+	return model.OutputDir, filename, extension, err
+}
+
+// RenderEntry renders the entry in the output format of the model: markdown with the entry fields in the front matter,
+// or JSON data file with the entry fields. The title, date and draft flag are added, unless the entry has fields with
+// the same names.
+func (l Layout) RenderEntry(model *midas.ModelSettings, payload midas.Payload) ([]byte, error) {
+	entry := payload.Entry()
+	draft, _ := payload.Metadata()["draft"].(bool)
+
+	predefined := map[string]interface{}{
+		"title": content.Title(model, entry),
+		"date":  content.Date(entry),
+		"draft": draft,
+	}
+
+	if model.Output == midas.OutputJSON {
+		return content.RenderData(payload, predefined)
+	}
+
+	return content.RenderMarkdown(model, payload, predefined)
+}
+
+func (l Layout) SinglePath(modelName string, model *midas.ModelSettings, _ string) string {
+	return filepath.Join(model.OutputDir, modelName+".json")
+}
+
+func (l Layout) RenderSingle(_ *midas.ModelSettings, payload midas.Payload) ([]byte, error) {
+	return content.RenderJSON(payload)
+}
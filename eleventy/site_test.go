@@ -0,0 +1,137 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package eleventy
+
+import (
+	"fmt"
+	"github.com/kovansky/midas"
+	"github.com/kovansky/midas/bluemonday"
+	"github.com/kovansky/midas/concurrent"
+	"github.com/kovansky/midas/content"
+	"github.com/kovansky/midas/mock"
+	"github.com/kovansky/midas/testing_utils"
+	"github.com/rs/zerolog"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLayout(t *testing.T) {
+	midas.Sanitizer = bluemonday.NewSanitizerService()
+
+	layout := Layout{}
+	payload := mock.NewPayload("post", map[string]interface{}{
+		"id": 1, "Title": "First", "Content": "<p>Hello</p>", "publishedAt": "2022-01-03T10:10:10.000Z",
+	}, "draft", false)
+
+	t.Run("Markdown", func(t *testing.T) {
+		model := &midas.ModelSettings{OutputDir: "src/posts"}
+
+		dir, filename, extension, err := layout.EntryPath("post", model, payload)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rendered, err := layout.RenderEntry(model, payload)
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Path":  {filepath.Join(dir, filename+extension), filepath.Join("src", "posts", "first.md")},
+			"Error": {err, nil},
+			"Content": {string(rendered), `---
+Title: First
+date: 2022-01-03T10:10:10Z
+draft: false
+id: 1
+publishedAt: 2022-01-03T10:10:10Z
+title: First
+---
+
+<p>Hello</p>
+`},
+		})
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		model := &midas.ModelSettings{OutputDir: "src/authors", Output: midas.OutputJSON, Filename: "{{ .Entry.id }}"}
+
+		dir, filename, extension, err := layout.EntryPath("author", model, payload)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rendered, err := layout.RenderEntry(model, payload)
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Path":  {filepath.Join(dir, filename+extension), filepath.Join("src", "authors", "1.json")},
+			"Error": {err, nil},
+			"Content": {string(rendered), `{
+  "Content": "<p>Hello</p>",
+  "Title": "First",
+  "date": "2022-01-03T10:10:10.000Z",
+  "draft": false,
+  "id": 1,
+  "publishedAt": "2022-01-03T10:10:10.000Z",
+  "title": "First"
+}
+`},
+		})
+	})
+
+	t.Run("Single", func(t *testing.T) {
+		path := layout.SinglePath("homepage", &midas.ModelSettings{OutputDir: "src/_data"}, "")
+
+		testing_utils.AssertEquals(t, path, filepath.Join("src", "_data", "homepage.json"), "Path")
+	})
+
+	t.Run("UnsupportedOutput", func(t *testing.T) {
+		_, _, _, err := layout.EntryPath("page", &midas.ModelSettings{Output: midas.OutputHTML}, payload)
+
+		testing_utils.AssertEquals(t, midas.ErrorCode(err), midas.ErrSiteConfig, "Error code")
+	})
+}
+
+func TestSiteService_BuildSite(t *testing.T) {
+	midas.Concurrents = concurrent.NewList()
+
+	root := t.TempDir()
+	log := filepath.Join(root, "eleventy.log")
+
+	// The stub records the arguments and environment of each run instead of building the site.
+	stub := filepath.Join(root, "eleventy.sh")
+	script := "#!/bin/sh\necho \"$@ env=$ELEVENTY_ENV\" >> " + log + "\n"
+	if err := os.WriteFile(stub, []byte(script), 0775); err != nil {
+		t.Fatal(err)
+	}
+
+	site := SiteService{content.Service{Site: midas.Site{
+		SiteName:       "eleventy",
+		RootDir:        root,
+		BuildDrafts:    true,
+		OutputSettings: midas.OutputSettings{Build: "_site", DraftEnvironment: "preview"},
+		Eleventy:       midas.EleventySettings{Command: []string{stub, "--quiet"}, Input: "src"},
+	}}}
+
+	if err := site.BuildSite(true, zerolog.Nop()); err != nil {
+		t.Fatal(err)
+	}
+
+	runs, err := os.ReadFile(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := fmt.Sprintf("--quiet --output=%s --input=src env=\n--quiet --output=%s --input=src env=preview\n",
+		filepath.Join(root, "_site"), filepath.Join(root, "publicDrafts"))
+
+	testing_utils.AssertEquals(t, string(runs), expected, "Runs")
+
+	t.Run("Failure", func(t *testing.T) {
+		site.Site.Eleventy.Command = []string{"false"}
+
+		testing_utils.AssertEquals(t, midas.ErrorCode(site.BuildSite(true, zerolog.Nop())), midas.ErrInternal, "Error code")
+	})
+}
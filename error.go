@@ -20,6 +20,9 @@ const (
 	ErrSiteConfig      = "site config"
 	ErrProcessNotFound = "process not found"
 	ErrCancelled       = "process cancelled"
+	ErrDeployment      = "deployment"
+	ErrDraftsBuild     = "drafts build"
+	ErrNotFound        = "not found"
 )
 
 // Error represents an application-specific error. App errors can be
@@ -0,0 +1,262 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package ftp
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"github.com/jlaffaye/ftp"
+	"github.com/kovansky/midas"
+	"github.com/kovansky/midas/walk"
+	"io"
+	"net"
+	"net/textproto"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Client struct {
+	ftpConfig midas.FTPDeploymentSettings
+	rootDir   string
+
+	closed bool
+	// createdDirs caches directories that are known to exist, so we don't send MKD for every uploaded file.
+	createdDirs map[string]struct{}
+
+	conn *ftp.ServerConn
+}
+
+// NewClient creates a new FTP client.
+//
+// The Client.Connect method must be called before using the client.
+func NewClient(ftpConfig midas.FTPDeploymentSettings) *Client {
+	rootDir := ftpConfig.Path
+	if rootDir == "" {
+		rootDir = "."
+	}
+
+	return &Client{
+		ftpConfig:   ftpConfig,
+		rootDir:     path.Clean(rootDir),
+		closed:      true,
+		createdDirs: make(map[string]struct{}),
+	}
+}
+
+// Connect establishes a connection to the remote server using FTP protocol (optionally secured with TLS) using given
+// configuration. Data connections are always established in passive mode.
+func (c *Client) Connect() error {
+	options := []ftp.DialOption{ftp.DialWithTimeout(30 * time.Second)}
+
+	port := 21
+	switch c.ftpConfig.TLS {
+	case "", "none":
+	case "explicit":
+		tlsConfig, err := c.tlsConfig()
+		if err != nil {
+			return err
+		}
+
+		options = append(options, ftp.DialWithExplicitTLS(tlsConfig))
+	case "implicit":
+		tlsConfig, err := c.tlsConfig()
+		if err != nil {
+			return err
+		}
+
+		options = append(options, ftp.DialWithTLS(tlsConfig))
+		port = 990
+	default:
+		return fmt.Errorf("unsupported ftp tls mode %s", c.ftpConfig.TLS)
+	}
+
+	if c.ftpConfig.Port != nil {
+		port = *c.ftpConfig.Port
+	}
+
+	addr := net.JoinHostPort(c.ftpConfig.Host, strconv.Itoa(port))
+
+	conn, err := ftp.Dial(addr, options...)
+	if err != nil {
+		return fmt.Errorf("could not connect to %s: %v", addr, err)
+	}
+
+	user := c.ftpConfig.User
+	if user == "" {
+		user = "anonymous"
+	}
+
+	if err = conn.Login(user, c.ftpConfig.Password); err != nil {
+		_ = conn.Quit()
+		return fmt.Errorf("could not log in to %s: %v", addr, err)
+	}
+
+	c.conn = conn
+	c.closed = false
+	return nil
+}
+
+// Close closes the connection to the remote server.
+func (c *Client) Close() error {
+	if !c.closed {
+		err := c.conn.Quit()
+		if err == nil {
+			c.closed = true
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// RemoteFiles returns a list of files in the remote directory.
+//
+// Listing uses MLSD command, which provides precise modification times. If the server doesn't announce MLST feature,
+// the client falls back to the LIST command.
+func (c *Client) RemoteFiles() (walk.FileMap, []error) {
+	var (
+		errs  []error
+		files = make(walk.FileMap)
+		queue = []string{c.rootDir}
+	)
+
+	for len(queue) > 0 {
+		dir := queue[0]
+		queue = queue[1:]
+
+		entries, err := c.conn.List(dir)
+		if err != nil {
+			// Website directory was not created yet, so there are no files.
+			var protoErr *textproto.Error
+			if dir == c.rootDir && errors.As(err, &protoErr) && protoErr.Code == ftp.StatusFileUnavailable {
+				break
+			}
+
+			errs = append(errs, fmt.Errorf("could not list remote directory %s: %v", dir, err))
+			continue
+		}
+
+		for _, entry := range entries {
+			// Skip current/parent directory entries, some servers send them with full path as a name.
+			if entry.Name == "." || entry.Name == ".." || strings.Contains(entry.Name, "/") {
+				continue
+			}
+
+			entryPath := path.Join(dir, entry.Name)
+
+			switch entry.Type {
+			case ftp.EntryTypeFolder:
+				queue = append(queue, entryPath)
+			case ftp.EntryTypeFile:
+				files[c.relativePath(entryPath)] = walk.RemoteFileInfo{
+					FileName:    entry.Name,
+					FileSize:    int64(entry.Size),
+					FileModTime: entry.Time,
+				}
+			}
+		}
+	}
+
+	return files, errs
+}
+
+// UploadNewFile creates a source file in the remote server.
+func (c *Client) UploadNewFile(filePath string, file io.Reader) error {
+	absolutePath := c.absolutePath(filePath)
+	dir := path.Dir(absolutePath)
+
+	// First we need to create all parent directories
+	if err := c.mkdirAll(dir); err != nil {
+		return fmt.Errorf("could not create directory %s in remote: %s", dir, err)
+	}
+
+	if err := c.conn.Stor(absolutePath, file); err != nil {
+		return fmt.Errorf("failed to copy file %s to remote: %s", filePath, err)
+	}
+
+	return nil
+}
+
+// RemoveFile removes a file from the remote server.
+func (c *Client) RemoveFile(filePath string) error {
+	absolutePath := c.absolutePath(filePath)
+
+	if err := c.conn.Delete(absolutePath); err != nil {
+		return fmt.Errorf("could not remove file %s from remote: %s", filePath, err)
+	}
+
+	return nil
+}
+
+// absolutePath returns the remote path of a file (relative to website root).
+func (c *Client) absolutePath(filePath string) string {
+	return path.Join(c.rootDir, filePath)
+}
+
+// relativePath returns the remote path relative to the website root.
+func (c *Client) relativePath(remotePath string) string {
+	if c.rootDir == "." {
+		return remotePath
+	}
+
+	return strings.TrimPrefix(strings.TrimPrefix(remotePath, c.rootDir), "/")
+}
+
+// mkdirAll creates the directory with all its parents. FTP has no way to tell if MKD failed because the directory
+// already exists, so errors are ignored here and will surface when the file is stored.
+func (c *Client) mkdirAll(dir string) error {
+	if dir == "." || dir == "/" {
+		return nil
+	}
+	if _, ok := c.createdDirs[dir]; ok {
+		return nil
+	}
+
+	if err := c.mkdirAll(path.Dir(dir)); err != nil {
+		return err
+	}
+
+	_ = c.conn.MakeDir(dir)
+	c.createdDirs[dir] = struct{}{}
+
+	return nil
+}
+
+// tlsConfig returns the TLS configuration for the connection, trusting the configured CA certificates.
+func (c *Client) tlsConfig() (*tls.Config, error) {
+	config := &tls.Config{
+		ServerName: c.ftpConfig.Host,
+		// Most servers require the data connection to resume the control connection session.
+		ClientSessionCache: tls.NewLRUClientSessionCache(0),
+	}
+
+	if c.ftpConfig.CACert != "" {
+		pem, err := os.ReadFile(c.ftpConfig.CACert)
+		if err != nil {
+			return nil, fmt.Errorf("could not read ca certificate: %v", err)
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", c.ftpConfig.CACert)
+		}
+
+		config.RootCAs = pool
+	}
+
+	return config, nil
+}
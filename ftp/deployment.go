@@ -0,0 +1,186 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package ftp
+
+import (
+	"fmt"
+	"github.com/kovansky/midas"
+	"github.com/kovansky/midas/walk"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var _ midas.Deployment = (*Deployment)(nil)
+
+type Deployment struct {
+	site               midas.Site
+	deploymentSettings midas.DeploymentSettings
+	publicPath         string
+
+	ftpClient *Client
+}
+
+func New(site midas.Site, deploymentSettings midas.DeploymentSettings, isDraft bool) (midas.Deployment, error) {
+	// Get build destination directory
+	var publicPath = site.PublicPath(isDraft)
+
+	return &Deployment{
+		site:               site,
+		deploymentSettings: deploymentSettings,
+		publicPath:         filepath.ToSlash(publicPath),
+
+		ftpClient: NewClient(deploymentSettings.FTP),
+	}, nil
+}
+
+// Deploy uploads the built files to the remote FTP server.
+func (d *Deployment) Deploy() error {
+	// Retrieve local files.
+	walker, err := d.retrieveFiles()
+	if err != nil {
+		return err
+	}
+
+	// And get local files as file map
+	fileMap, err := d.getFileMap(walker)
+	if err != nil {
+		return err
+	}
+
+	err = d.ftpClient.Connect()
+	if err != nil {
+		return err
+	}
+	defer func(ftpClient *Client) {
+		_ = ftpClient.Close()
+	}(d.ftpClient)
+
+	// Get remote files.
+	remoteFiles, err := d.remoteFiles()
+	if err != nil {
+		return err
+	}
+
+	// Generate diffs
+	diff := fileMap.Diff(remoteFiles)
+
+	for _, fileOp := range diff {
+		err := d.syncFile(fileOp)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Plan returns the changes Deploy would make on the remote FTP server, without changing anything.
+func (d *Deployment) Plan() (*midas.DeploymentPlan, error) {
+	// Retrieve local files.
+	walker, err := d.retrieveFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	fileMap, err := d.getFileMap(walker)
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.ftpClient.Connect()
+	if err != nil {
+		return nil, err
+	}
+	defer func(ftpClient *Client) {
+		_ = ftpClient.Close()
+	}(d.ftpClient)
+
+	remoteFiles, err := d.remoteFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	return walk.Plan(fileMap.Diff(remoteFiles), d.ftpClient.absolutePath), nil
+}
+
+// syncFile performs a file operation.
+func (d *Deployment) syncFile(operation walk.FileOperation) error {
+	switch operation.Type {
+	case walk.UploadFile, walk.UpdateFile:
+		absolute := filepath.ToSlash(filepath.Clean(filepath.Join(d.publicPath, operation.Path)))
+
+		handler, err := os.Open(absolute)
+		if err != nil {
+			return err
+		}
+		defer func(handler *os.File) {
+			_ = handler.Close()
+		}(handler)
+
+		if err = d.ftpClient.UploadNewFile(operation.Path, handler); err != nil {
+			return err
+		}
+
+		break
+	case walk.RemoveFile:
+		if err := d.ftpClient.RemoveFile(operation.Path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// remoteFiles returns a map of remote files indexed by their relative path. The client has to be connected.
+func (d *Deployment) remoteFiles() (walk.FileMap, error) {
+	files, errors := d.ftpClient.RemoteFiles()
+	if errors != nil {
+		var errorsString []string
+		for _, err := range errors {
+			errorsString = append(errorsString, err.Error())
+		}
+
+		return nil, fmt.Errorf("errors getting remote files: %s", strings.Join(errorsString, "\n"))
+	}
+
+	return files, nil
+}
+
+// retrieveFiles walks the public directory and returns a channel of files to be uploaded.
+func (d *Deployment) retrieveFiles() (walk.FileWalk, error) {
+	walker := make(walk.FileWalk)
+
+	// Gather the files to upload by walking the path recursively.
+	go func() {
+		defer close(walker)
+		if err := filepath.Walk(d.publicPath, walker.Walk); err != nil {
+			panic(err)
+		}
+	}()
+
+	return walker, nil
+}
+
+// getFileMap returns locally retrieved files in form of a fileMap indexed by their relative path.
+func (d *Deployment) getFileMap(fileWalk walk.FileWalk) (walk.FileMap, error) {
+	fileMap := make(walk.FileMap)
+
+	for file := range fileWalk {
+		fileInfo, err := os.Stat(file)
+		if err != nil {
+			return nil, err
+		}
+
+		relPath, _ := filepath.Rel(d.publicPath, file)
+		relPath = filepath.ToSlash(relPath)
+
+		fileMap[relPath] = fileInfo
+	}
+
+	return fileMap, nil
+}
@@ -0,0 +1,386 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package ftp
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"github.com/kovansky/midas"
+	"github.com/kovansky/midas/testing_utils"
+	"io"
+	"math/big"
+	"net"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testUser     = "midas"
+	testPassword = "secret"
+)
+
+func TestDeployment_Deploy(t *testing.T) {
+	certificate, caFile := generateCertificate(t)
+
+	for _, mode := range []string{"none", "explicit", "implicit"} {
+		t.Run(mode, func(t *testing.T) {
+			remoteDir := t.TempDir()
+			server := newTestServer(t, remoteDir, mode, certificate)
+			defer server.Close()
+
+			port := server.Port()
+			site := midas.Site{RootDir: t.TempDir()}
+			settings := midas.DeploymentSettings{
+				Enabled: true,
+				Target:  "ftp",
+				FTP: midas.FTPDeploymentSettings{
+					Host:     "127.0.0.1",
+					Port:     &port,
+					User:     testUser,
+					Password: testPassword,
+					TLS:      mode,
+					CACert:   caFile,
+					Path:     "/www/mysite",
+				},
+			}
+			publicPath := site.PublicPath(false)
+			remotePath := filepath.Join(remoteDir, "www", "mysite")
+
+			writeFiles(t, publicPath, map[string]string{
+				"index.html":             "home",
+				"css/style.css":          "body {}",
+				"posts/first/index.html": "first",
+			})
+
+			deployment, err := New(site, settings, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			t.Run("Initial", func(t *testing.T) {
+				if err := deployment.Deploy(); err != nil {
+					t.Fatal(err)
+				}
+
+				testing_utils.AssertTable(t, map[string][]interface{}{
+					"index.html":             {readFile(t, remotePath, "index.html"), "home"},
+					"css/style.css":          {readFile(t, remotePath, "css/style.css"), "body {}"},
+					"posts/first/index.html": {readFile(t, remotePath, "posts/first/index.html"), "first"},
+				})
+			})
+
+			t.Run("Sync", func(t *testing.T) {
+				if err := os.RemoveAll(filepath.Join(publicPath, "posts", "first")); err != nil {
+					t.Fatal(err)
+				}
+				writeFiles(t, publicPath, map[string]string{
+					"index.html":              "new home",
+					"posts/second/index.html": "second",
+				})
+
+				// Remote modification times are set on upload, so make sure the local change is newer.
+				future := time.Now().Add(time.Hour)
+				if err := os.Chtimes(filepath.Join(publicPath, "index.html"), future, future); err != nil {
+					t.Fatal(err)
+				}
+
+				plan, err := deployment.Plan()
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				testing_utils.AssertTable(t, map[string][]interface{}{
+					"Plan upload":        {strings.Join(plan.Upload, ","), "/www/mysite/posts/second/index.html"},
+					"Plan update":        {strings.Join(plan.Update, ","), "/www/mysite/index.html"},
+					"Plan delete":        {strings.Join(plan.Delete, ","), "/www/mysite/posts/first/index.html"},
+					"Remote not changed": {readFile(t, remotePath, "index.html"), "home"},
+				})
+
+				if err := deployment.Deploy(); err != nil {
+					t.Fatal(err)
+				}
+
+				_, firstErr := os.Stat(filepath.Join(remotePath, "posts", "first", "index.html"))
+
+				testing_utils.AssertTable(t, map[string][]interface{}{
+					"index.html":              {readFile(t, remotePath, "index.html"), "new home"},
+					"posts/second/index.html": {readFile(t, remotePath, "posts/second/index.html"), "second"},
+					"posts/first removed":     {os.IsNotExist(firstErr), true},
+				})
+			})
+		})
+	}
+}
+
+// testServer is a minimal in-process FTP server, implementing only the commands used by the deployment.
+// Data connections are passive only (EPSV).
+type testServer struct {
+	t        *testing.T
+	root     string
+	mode     string
+	tls      *tls.Config
+	listener net.Listener
+}
+
+func newTestServer(t *testing.T, root, mode string, certificate tls.Certificate) *testServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &testServer{
+		t:        t,
+		root:     root,
+		mode:     mode,
+		tls:      &tls.Config{Certificates: []tls.Certificate{certificate}},
+		listener: listener,
+	}
+
+	go s.serve()
+
+	return s
+}
+
+func (s *testServer) Port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *testServer) Close() {
+	_ = s.listener.Close()
+}
+
+func (s *testServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go s.handle(conn)
+	}
+}
+
+func (s *testServer) handle(conn net.Conn) {
+	defer conn.Close()
+
+	if s.mode == "implicit" {
+		conn = tls.Server(conn, s.tls)
+	}
+
+	var (
+		control   = textproto.NewConn(conn)
+		passive   net.Listener
+		protected = s.mode == "implicit"
+	)
+	defer func() {
+		if passive != nil {
+			_ = passive.Close()
+		}
+	}()
+
+	reply := func(format string, args ...interface{}) {
+		_ = control.PrintfLine(format, args...)
+	}
+
+	// acceptData accepts the data connection opened by the client after EPSV.
+	acceptData := func() (net.Conn, error) {
+		if passive == nil {
+			return nil, fmt.Errorf("no passive listener")
+		}
+
+		dataConn, err := passive.Accept()
+		_ = passive.Close()
+		passive = nil
+		if err != nil {
+			return nil, err
+		}
+
+		if protected {
+			return tls.Server(dataConn, s.tls), nil
+		}
+		return dataConn, nil
+	}
+
+	reply("220 midas test server ready")
+
+	for {
+		line, err := control.ReadLine()
+		if err != nil {
+			return
+		}
+
+		command, arg, _ := strings.Cut(line, " ")
+		localPath := filepath.Join(s.root, filepath.FromSlash(arg))
+
+		switch strings.ToUpper(command) {
+		case "AUTH":
+			reply("234 AUTH TLS successful")
+			conn = tls.Server(conn, s.tls)
+			control = textproto.NewConn(conn)
+		case "USER":
+			reply("331 password required")
+		case "PASS":
+			if arg != testPassword {
+				reply("530 login incorrect")
+				continue
+			}
+			reply("230 logged in")
+		case "FEAT":
+			reply("211-Features:")
+			reply(" MLST type*;size*;modify*;")
+			reply(" UTF8")
+			reply("211 End")
+		case "TYPE", "OPTS", "PBSZ":
+			reply("200 OK")
+		case "PROT":
+			protected = arg == "P"
+			reply("200 OK")
+		case "EPSV":
+			if passive, err = net.Listen("tcp", "127.0.0.1:0"); err != nil {
+				reply("425 can't open passive connection")
+				continue
+			}
+			reply("229 Entering Extended Passive Mode (|||%d|)", passive.Addr().(*net.TCPAddr).Port)
+		case "MLSD":
+			entries, err := os.ReadDir(localPath)
+			if err != nil {
+				reply("550 no such directory")
+				continue
+			}
+
+			reply("150 opening data connection")
+			dataConn, err := acceptData()
+			if err != nil {
+				reply("425 can't open data connection")
+				continue
+			}
+
+			for _, entry := range entries {
+				info, _ := entry.Info()
+				entryType := "file"
+				if entry.IsDir() {
+					entryType = "dir"
+				}
+
+				_, _ = fmt.Fprintf(dataConn, "type=%s;size=%d;modify=%s; %s\r\n",
+					entryType, info.Size(), info.ModTime().UTC().Format("20060102150405"), entry.Name())
+			}
+
+			_ = dataConn.Close()
+			reply("226 transfer complete")
+		case "STOR":
+			reply("150 opening data connection")
+			dataConn, err := acceptData()
+			if err != nil {
+				reply("425 can't open data connection")
+				continue
+			}
+
+			file, err := os.Create(localPath)
+			if err != nil {
+				_ = dataConn.Close()
+				reply("550 can't create file")
+				continue
+			}
+
+			_, _ = io.Copy(file, dataConn)
+			_ = file.Close()
+			_ = dataConn.Close()
+			reply("226 transfer complete")
+		case "DELE":
+			if err := os.Remove(localPath); err != nil {
+				reply("550 can't remove file")
+				continue
+			}
+			reply("250 file removed")
+		case "MKD":
+			if err := os.Mkdir(localPath, 0775); err != nil {
+				reply("550 can't create directory")
+				continue
+			}
+			reply("257 \"%s\" created", arg)
+		case "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("502 command not implemented")
+		}
+	}
+}
+
+// generateCertificate creates a self-signed certificate for 127.0.0.1 and saves it in a PEM file,
+// so it can be used as a trusted CA.
+func generateCertificate(t *testing.T) (tls.Certificate, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "midas test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err = os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0664); err != nil {
+		t.Fatal(err)
+	}
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, caFile
+}
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+
+		if err := os.MkdirAll(filepath.Dir(path), 0775); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0664); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func readFile(t *testing.T, root, name string) string {
+	t.Helper()
+
+	content, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(name)))
+	if err != nil {
+		return ""
+	}
+
+	return string(content)
+}
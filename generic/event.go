@@ -0,0 +1,42 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package generic
+
+type Event int64
+
+const (
+	Undefined Event = iota
+	Create
+	Update
+	Delete
+)
+
+var fromName = map[string]Event{
+	"create": Create,
+	"update": Update,
+	"delete": Delete,
+}
+
+var toString = map[Event]string{
+	Undefined: "",
+	Create:    "Create",
+	Update:    "Update",
+	Delete:    "Delete",
+}
+
+// ParseEvent returns the event of the value of the event type, mapped by the events of the generic settings.
+func ParseEvent(value string, events map[string]string) Event {
+	if name, ok := events[value]; ok {
+		value = name
+	}
+
+	return fromName[value]
+}
+
+func (event Event) String() string {
+	return toString[event]
+}
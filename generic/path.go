@@ -0,0 +1,103 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package generic
+
+import (
+	"github.com/kovansky/midas"
+	"strconv"
+	"strings"
+)
+
+// Path is the path of the value in the JSON body. Its segments are the keys of the objects (strings) and the indexes
+// of the arrays (ints).
+type Path []interface{}
+
+// ParsePath parses the path in JSONPath ($.data.items[0].id) or jq (.data.items[0].id) notation. Keys with dots or
+// brackets are quoted in brackets, i.e. $["content.type"]. Empty path, $ and . are the whole body.
+func ParsePath(path string) (Path, error) {
+	rest := strings.TrimPrefix(strings.TrimSpace(path), "$")
+	parsed := Path{}
+
+	for rest != "" {
+		switch rest[0] {
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, midas.Errorf(midas.ErrSiteConfig, "path %s is invalid: unclosed bracket", path)
+			}
+
+			segment := rest[1:end]
+			rest = rest[end+1:]
+
+			if len(segment) >= 2 && (segment[0] == '"' || segment[0] == '\'') && segment[len(segment)-1] == segment[0] {
+				parsed = append(parsed, segment[1:len(segment)-1])
+				continue
+			}
+
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 {
+				return nil, midas.Errorf(midas.ErrSiteConfig, "path %s is invalid: %s is not an index", path, segment)
+			}
+
+			parsed = append(parsed, index)
+		case '.':
+			rest = rest[1:]
+			// The dot of jq may be followed by the bracket, or be the whole path.
+			if (rest == "" && len(parsed) == 0) || strings.HasPrefix(rest, "[") {
+				continue
+			}
+
+			fallthrough
+		default:
+			if len(parsed) > 0 && rest == "" {
+				return nil, midas.Errorf(midas.ErrSiteConfig, "path %s is invalid: trailing dot", path)
+			}
+
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+
+			if end == 0 {
+				return nil, midas.Errorf(midas.ErrSiteConfig, "path %s is invalid: empty key", path)
+			}
+
+			parsed = append(parsed, rest[:end])
+			rest = rest[end:]
+		}
+	}
+
+	return parsed, nil
+}
+
+// Lookup returns the value at the path in the decoded JSON body, and whether it exists.
+func (p Path) Lookup(body interface{}) (interface{}, bool) {
+	value := body
+
+	for _, segment := range p {
+		switch segment := segment.(type) {
+		case string:
+			object, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, false
+			}
+
+			if value, ok = object[segment]; !ok {
+				return nil, false
+			}
+		case int:
+			array, ok := value.([]interface{})
+			if !ok || segment >= len(array) {
+				return nil, false
+			}
+
+			value = array[segment]
+		}
+	}
+
+	return value, true
+}
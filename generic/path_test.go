@@ -0,0 +1,77 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package generic
+
+import (
+	"fmt"
+	"github.com/kovansky/midas"
+	"github.com/kovansky/midas/testing_utils"
+	"reflect"
+	"testing"
+)
+
+func TestParsePath(t *testing.T) {
+	paths := map[string]Path{
+		"":                       {},
+		"$":                      {},
+		".":                      {},
+		"$.data.id":              {"data", "id"},
+		".data.id":               {"data", "id"},
+		"data.id":                {"data", "id"},
+		"$.items[1].id":          {"items", 1, "id"},
+		".[0]":                   {0},
+		`$["content.type"].name`: {"content.type", "name"},
+		".['key'][2]":            {"key", 2},
+	}
+
+	for path, expected := range paths {
+		parsed, err := ParsePath(path)
+		if err != nil {
+			t.Fatalf("%s: %v", path, err)
+		}
+
+		testing_utils.AssertEquals(t, reflect.DeepEqual(parsed, expected), true, path)
+	}
+
+	for _, path := range []string{"$.data.", "$..id", "$.items[x]", "$.items[0"} {
+		_, err := ParsePath(path)
+
+		testing_utils.AssertEquals(t, midas.ErrorCode(err), midas.ErrSiteConfig, path)
+	}
+}
+
+func TestPath_Lookup(t *testing.T) {
+	body := map[string]interface{}{
+		"data": map[string]interface{}{
+			"items": []interface{}{
+				map[string]interface{}{"id": "first"},
+				map[string]interface{}{"id": nil},
+			},
+		},
+	}
+
+	// lookup returns the value at the path, and whether it exists, formatted for the comparison.
+	lookup := func(path string) string {
+		parsed, err := ParsePath(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		value, ok := parsed.Lookup(body)
+
+		return fmt.Sprintf("%v %v", value, ok)
+	}
+
+	testing_utils.AssertTable(t, map[string][]interface{}{
+		"Value":          {lookup("$.data.items[0].id"), "first true"},
+		"Null value":     {lookup("$.data.items[1].id"), "<nil> true"},
+		"Missing key":    {lookup("$.data.title"), "<nil> false"},
+		"Out of range":   {lookup("$.data.items[2].id"), "<nil> false"},
+		"Key of array":   {lookup("$.data.items.id"), "<nil> false"},
+		"Index of value": {lookup("$.data.items[0].id[0]"), "<nil> false"},
+	})
+}
@@ -0,0 +1,178 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package generic
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/kovansky/midas"
+	"math"
+)
+
+var _ midas.Payload = (*Payload)(nil)
+
+// Payload is the payload of the generic webhook, with the event, model, id, publication state and entry picked from
+// the body by the paths of the generic settings.
+type Payload struct {
+	event     Event
+	Model     string
+	id        interface{}
+	published bool
+	body      interface{}
+	metadata  map[string]interface{}
+	entry     map[string]interface{}
+}
+
+// ParsePayload parses the webhook body, mapped by the settings.
+func ParsePayload(settings midas.GenericSettings, json []byte) (*Payload, error) {
+	if settings.Event == "" || settings.Model == "" || settings.Id == "" {
+		return nil, midas.Errorf(midas.ErrSiteConfig, "generic settings require the event, model and id paths")
+	}
+
+	payload := Payload{}
+	if err := payload.UnmarshalJSON(json); err != nil {
+		return nil, err
+	}
+
+	eventValue, err := payload.lookup(settings.Event)
+	if err != nil {
+		return nil, err
+	}
+
+	payload.event = ParseEvent(fmt.Sprint(eventValue), settings.Events)
+	if payload.event == Undefined {
+		return nil, midas.Errorf(midas.ErrInvalid, "event %v is not supported", eventValue)
+	}
+
+	model, err := payload.lookup(settings.Model)
+	if err != nil {
+		return nil, err
+	}
+
+	if payload.Model, _ = model.(string); payload.Model == "" {
+		return nil, midas.Errorf(midas.ErrInvalid, "model %v is invalid", model)
+	}
+
+	if payload.id, err = payload.lookup(settings.Id); err != nil {
+		return nil, err
+	} else if payload.id == nil {
+		return nil, midas.Errorf(midas.ErrInvalid, "entry has no id")
+	} else if id, ok := payload.id.(float64); ok && id == math.Trunc(id) {
+		// Numeric ids are decoded as floats, which are formatted with exponents (i.e. 1e+06) in the registry ids.
+		payload.id = int64(id)
+	}
+
+	payload.published = true
+	if settings.Published != "" {
+		path, err := ParsePath(settings.Published)
+		if err != nil {
+			return nil, err
+		}
+
+		published, _ := path.Lookup(payload.body)
+		payload.published = truthy(published)
+	}
+
+	path, err := ParsePath(settings.Entry)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, ok := path.Lookup(payload.body)
+	if payload.entry, _ = entry.(map[string]interface{}); payload.entry == nil {
+		// Deleted entries may be sent without the entry.
+		if (ok && entry != nil) || payload.event != Delete {
+			return nil, midas.Errorf(midas.ErrInvalid, "entry is not an object")
+		}
+
+		payload.entry = make(map[string]interface{})
+	}
+
+	payload.createMetadataMap()
+
+	return &payload, nil
+}
+
+// lookup returns the value at the path of the body, which has to exist.
+func (p Payload) lookup(path string) (interface{}, error) {
+	parsed, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	value, ok := parsed.Lookup(p.body)
+	if !ok {
+		return nil, midas.Errorf(midas.ErrInvalid, "payload has no value at %s", path)
+	}
+
+	return value, nil
+}
+
+// truthy tells if the value of the publication state means the entry is published: false, null, empty strings and
+// zeros are not.
+func truthy(value interface{}) bool {
+	switch value := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return value
+	case string:
+		return value != ""
+	case float64:
+		return value != 0
+	default:
+		return true
+	}
+}
+
+func (p Payload) Event() string {
+	return p.event.String()
+}
+
+func (p Payload) Metadata() map[string]interface{} {
+	return p.metadata
+}
+
+func (p *Payload) createMetadataMap() {
+	asMap := make(map[string]interface{})
+
+	asMap["event"] = p.event
+	asMap["model"] = p.Model
+	asMap["entryId"] = p.id
+	asMap["published"] = p.published
+	asMap["draft"] = !p.published
+
+	p.metadata = asMap
+}
+
+func (p Payload) Entry() map[string]interface{} {
+	return p.entry
+}
+
+func (p *Payload) SetEntry(entry map[string]interface{}) {
+	p.entry = entry
+}
+
+func (p Payload) Raw() interface{} {
+	return p
+}
+
+// MarshalJSON marshals the entry mapped from the body (as set by SetEntry), so the data files hold the sanitized entry
+// instead of the whole webhook body.
+func (p Payload) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.entry)
+}
+
+func (p *Payload) UnmarshalJSON(bytes []byte) error {
+	var body interface{}
+	if err := json.Unmarshal(bytes, &body); err != nil {
+		return err
+	}
+
+	*p = Payload{body: body}
+	return nil
+}
@@ -0,0 +1,141 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package generic
+
+import (
+	"encoding/json"
+	"github.com/kovansky/midas"
+	"github.com/kovansky/midas/bluemonday"
+	"github.com/kovansky/midas/content"
+	"github.com/kovansky/midas/testing_utils"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var settings = midas.GenericSettings{
+	Event: "$.type",
+	Events: map[string]string{
+		"article.created": "create",
+		"article.saved":   "update",
+		"article.removed": "delete",
+	},
+	Model:     ".resource.kind",
+	Id:        "$.resource.key",
+	Published: "$.resource.state.publishedAt",
+	Entry:     "$.data",
+}
+
+func readSample(t *testing.T, name string) []byte {
+	data, err := os.ReadFile(filepath.Join("testdata", name+".json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return data
+}
+
+func TestParsePayload(t *testing.T) {
+	t.Run("Update", func(t *testing.T) {
+		payload, err := ParsePayload(settings, readSample(t, "update"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tags, _ := payload.Entry()["tags"].([]interface{})
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Event":     {payload.Event(), Update.String()},
+			"Model":     {payload.Metadata()["model"], "article"},
+			"Entry id":  {payload.Metadata()["entryId"], int64(1000000)},
+			"Published": {payload.Metadata()["published"], true},
+			"Draft":     {payload.Metadata()["draft"], false},
+			"Title":     {payload.Entry()["title"], "Hello World"},
+			"Tags":      {len(tags), 2},
+		})
+	})
+
+	t.Run("Draft", func(t *testing.T) {
+		payload, err := ParsePayload(settings, readSample(t, "draft"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Event":     {payload.Event(), Create.String()},
+			"Published": {payload.Metadata()["published"], false},
+			"Draft":     {payload.Metadata()["draft"], true},
+		})
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		payload, err := ParsePayload(settings, readSample(t, "delete"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Event":        {payload.Event(), Delete.String()},
+			"Entry id":     {payload.Metadata()["entryId"], int64(1000000)},
+			"Entry length": {len(payload.Entry()), 0},
+		})
+	})
+
+	t.Run("WholeBody", func(t *testing.T) {
+		payload, err := ParsePayload(midas.GenericSettings{Event: "$.event", Model: "$.model", Id: "$.id"},
+			[]byte(`{"event": "create", "model": "page", "id": "about", "title": "About"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Event":     {payload.Event(), Create.String()},
+			"Entry id":  {payload.Metadata()["entryId"], "about"},
+			"Published": {payload.Metadata()["published"], true},
+			"Title":     {payload.Entry()["title"], "About"},
+		})
+	})
+
+	t.Run("RenderJSON", func(t *testing.T) {
+		midas.Sanitizer = bluemonday.NewSanitizerService()
+
+		payload, err := ParsePayload(settings, readSample(t, "update"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		payload.entry["title"] = "Hello<script>alert(1)</script>"
+
+		rendered, err := content.RenderJSON(payload)
+
+		var data map[string]interface{}
+		jsonErr := json.Unmarshal(rendered, &data)
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Error":      {err, nil},
+			"JSON error": {jsonErr, nil},
+			"Title":      {data["title"], "Hello"},
+			"No event":   {data["type"], nil},
+		})
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		errorCode := func(settings midas.GenericSettings, body string) string {
+			_, err := ParsePayload(settings, []byte(body))
+
+			return midas.ErrorCode(err)
+		}
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"No settings":      {errorCode(midas.GenericSettings{}, `{}`), midas.ErrSiteConfig},
+			"Unknown event":    {errorCode(settings, `{"type": "article.viewed", "resource": {"kind": "article", "key": 1}, "data": {}}`), midas.ErrInvalid},
+			"Missing model":    {errorCode(settings, `{"type": "article.saved", "resource": {"key": 1}, "data": {}}`), midas.ErrInvalid},
+			"Missing id":       {errorCode(settings, `{"type": "article.saved", "resource": {"kind": "article"}, "data": {}}`), midas.ErrInvalid},
+			"Missing entry":    {errorCode(settings, `{"type": "article.saved", "resource": {"kind": "article", "key": 1}}`), midas.ErrInvalid},
+			"Entry not object": {errorCode(settings, `{"type": "article.saved", "resource": {"kind": "article", "key": 1}, "data": []}`), midas.ErrInvalid},
+		})
+	})
+}
@@ -0,0 +1,114 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"github.com/kovansky/midas/contentful"
+	"github.com/kovansky/midas/testing_utils"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestServer_HandleWebhook_Contentful(t *testing.T) {
+	endpoint := "/contentful/hugo"
+
+	s := SetUp(t)
+	defer MustCloseServer(t, s)
+
+	// request sends the recorded sample payload of the topic.
+	request := func(t *testing.T, site, topic, sample string) *http.Response {
+		body, err := os.ReadFile(filepath.Join("..", "contentful", "testdata", sample+".json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req := s.MustNewRequest(t, context.Background(), site, "POST", endpoint, bytes.NewReader(body))
+		req.Header.Set(contentful.TopicHeader, topic)
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return resp
+	}
+
+	t.Run("Publish", func(t *testing.T) {
+		resp := request(t, "test", "ContentManagement.Entry.publish", "publish")
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Status code":      {resp.StatusCode, http.StatusNoContent},
+			"Site.UpdateEntry": {MockSiteCounters["UpdateEntry"], 1},
+			"Site.BuildSite":   {MockSiteCounters["BuildSite"], 1},
+			"Entry id":         {MockLastPayload.Metadata()["entryId"], "5KsDBWseXY6QegucYAoacS"},
+			"Locale":           {MockLastPayload.Metadata()["locale"], nil},
+		})
+	})
+
+	resetCounters()
+
+	t.Run("PublishLocalized", func(t *testing.T) {
+		resp := request(t, "contentful", "ContentManagement.Entry.publish", "publish")
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Status code":      {resp.StatusCode, http.StatusNoContent},
+			"Site.UpdateEntry": {MockSiteCounters["UpdateEntry"], 2},
+			"Site.BuildSite":   {MockSiteCounters["BuildSite"], 1},
+			"Last locale":      {MockLastPayload.Metadata()["locale"], "en-US"},
+			"Last title":       {MockLastPayload.Entry()["title"], "Hello World"},
+		})
+	})
+
+	resetCounters()
+
+	t.Run("Unpublish", func(t *testing.T) {
+		resp := request(t, "test", "ContentManagement.Entry.unpublish", "unpublish")
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Status code":      {resp.StatusCode, http.StatusNoContent},
+			"Site.DeleteEntry": {MockSiteCounters["DeleteEntry"], 1},
+			"Site.BuildSite":   {MockSiteCounters["BuildSite"], 1},
+		})
+	})
+
+	resetCounters()
+
+	t.Run("DeleteLocalized", func(t *testing.T) {
+		resp := request(t, "contentful", "ContentManagement.Entry.delete", "delete")
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Status code":      {resp.StatusCode, http.StatusNoContent},
+			"Site.DeleteEntry": {MockSiteCounters["DeleteEntry"], 2},
+			"Site.BuildSite":   {MockSiteCounters["BuildSite"], 1},
+		})
+	})
+
+	resetCounters()
+
+	t.Run("UnsupportedTopic", func(t *testing.T) {
+		resp := request(t, "test", "ContentManagement.Entry.save", "publish")
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Status code":    {resp.StatusCode, http.StatusBadRequest},
+			"Site.BuildSite": {MockSiteCounters["BuildSite"], 0},
+		})
+	})
+
+	resetCounters()
+
+	t.Run("ServiceMismatch", func(t *testing.T) {
+		resp := request(t, "otherService", "ContentManagement.Entry.publish", "publish")
+
+		testing_utils.AssertEquals(t, resp.StatusCode, http.StatusBadRequest, "Status code")
+	})
+
+	resetCounters()
+}
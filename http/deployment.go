@@ -0,0 +1,59 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package http
+
+import (
+	"encoding/json"
+	"github.com/go-chi/httplog"
+	"github.com/kovansky/midas"
+	"net/http"
+)
+
+// DeploymentsResponse is returned by the endpoints which run deployments.
+type DeploymentsResponse struct {
+	Status      string                   `json:"status"`
+	Deployments []midas.DeploymentResult `json:"deployments,omitempty"`
+}
+
+// DeploymentsErrorResponse is returned when any of the required deployments failed.
+type DeploymentsErrorResponse struct {
+	Error       string                   `json:"error"`
+	Deployments []midas.DeploymentResult `json:"deployments,omitempty"`
+}
+
+// Deployments writes per-target deployment results. If there were no deployments, only emptyStatus is written.
+func Deployments(w http.ResponseWriter, emptyStatus int, results []midas.DeploymentResult) {
+	if len(results) == 0 {
+		w.WriteHeader(emptyStatus)
+		return
+	}
+
+	jsoned, _ := json.Marshal(&DeploymentsResponse{Status: "ok", Deployments: results})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(jsoned)
+}
+
+// DeploymentsError prints the error along with per-target deployment results. Errors other than ErrDeployment are
+// handled by Error.
+func DeploymentsError(w http.ResponseWriter, r *http.Request, err error, results []midas.DeploymentResult) {
+	code := midas.ErrorCode(err)
+	if code != midas.ErrDeployment {
+		Error(w, r, err)
+		return
+	}
+
+	jsonError, _ := json.Marshal(&DeploymentsErrorResponse{Error: midas.ErrorMessage(err), Deployments: results})
+
+	w.Header().Set("Content-type", "application/json")
+	w.WriteHeader(ErrorStatusCode(code))
+	_, _ = w.Write(jsonError)
+
+	log := httplog.LogEntry(r.Context())
+	log.Error().Err(err).Msg("details of following errored request")
+}
@@ -0,0 +1,89 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"github.com/kovansky/midas/testing_utils"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestServer_HandleWebhook_Directus(t *testing.T) {
+	endpoint := "/directus/hugo"
+
+	s := SetUp(t)
+	defer MustCloseServer(t, s)
+
+	// request sends the recorded sample payload.
+	request := func(t *testing.T, site, sample string) *http.Response {
+		body, err := os.ReadFile(filepath.Join("..", "directus", "testdata", sample+".json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		resp, err := http.DefaultClient.Do(s.MustNewRequest(t, context.Background(), site, "POST", endpoint, bytes.NewReader(body)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return resp
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		resp := request(t, "headless", "create")
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Status code":      {resp.StatusCode, http.StatusNoContent},
+			"Site.CreateEntry": {MockSiteCounters["CreateEntry"], 1},
+			"Site.BuildSite":   {MockSiteCounters["BuildSite"], 1},
+		})
+	})
+
+	resetCounters()
+
+	t.Run("Update", func(t *testing.T) {
+		resp := request(t, "headless", "update")
+
+		// The second item doesn't exist anymore.
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Status code":      {resp.StatusCode, http.StatusNoContent},
+			"Site.UpdateEntry": {MockSiteCounters["UpdateEntry"], 1},
+			"Site.BuildSite":   {MockSiteCounters["BuildSite"], 1},
+			"Fetched title":    {MockLastPayload.Entry()["title"], "Fetched post"},
+			"Entry id":         {MockLastPayload.Metadata()["entryId"], "5"},
+		})
+	})
+
+	resetCounters()
+
+	t.Run("Delete", func(t *testing.T) {
+		resp := request(t, "headless", "delete")
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Status code":      {resp.StatusCode, http.StatusNoContent},
+			"Site.DeleteEntry": {MockSiteCounters["DeleteEntry"], 1},
+			"Site.BuildSite":   {MockSiteCounters["BuildSite"], 1},
+		})
+	})
+
+	resetCounters()
+
+	t.Run("UnsupportedModel", func(t *testing.T) {
+		resp := request(t, "test", "create")
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Status code":    {resp.StatusCode, http.StatusBadRequest},
+			"Site.BuildSite": {MockSiteCounters["BuildSite"], 0},
+		})
+	})
+
+	resetCounters()
+}
@@ -0,0 +1,108 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"github.com/kovansky/midas/testing_utils"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestServer_HandleWebhook_Generic(t *testing.T) {
+	endpoint := "/generic/hugo"
+
+	s := SetUp(t)
+	defer MustCloseServer(t, s)
+
+	// request sends the sample payload.
+	request := func(t *testing.T, sample string) *http.Response {
+		body, err := os.ReadFile(filepath.Join("..", "generic", "testdata", sample+".json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		resp, err := http.DefaultClient.Do(s.MustNewRequest(t, context.Background(), "generic", "POST", endpoint, bytes.NewReader(body)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return resp
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		resp := request(t, "draft")
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Status code":      {resp.StatusCode, http.StatusNoContent},
+			"Site.CreateEntry": {MockSiteCounters["CreateEntry"], 1},
+			"Site.BuildSite":   {MockSiteCounters["BuildSite"], 1},
+		})
+	})
+
+	resetCounters()
+
+	t.Run("Update", func(t *testing.T) {
+		resp := request(t, "update")
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Status code":      {resp.StatusCode, http.StatusNoContent},
+			"Site.UpdateEntry": {MockSiteCounters["UpdateEntry"], 1},
+			"Site.BuildSite":   {MockSiteCounters["BuildSite"], 1},
+			"Title":            {MockLastPayload.Entry()["title"], "Hello World"},
+			"Draft metadata":   {MockLastPayload.Metadata()["draft"], false},
+		})
+	})
+
+	resetCounters()
+
+	t.Run("Delete", func(t *testing.T) {
+		resp := request(t, "delete")
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Status code":      {resp.StatusCode, http.StatusNoContent},
+			"Site.DeleteEntry": {MockSiteCounters["DeleteEntry"], 1},
+			"Site.BuildSite":   {MockSiteCounters["BuildSite"], 1},
+		})
+	})
+
+	resetCounters()
+
+	t.Run("UnsupportedModel", func(t *testing.T) {
+		body := strings.NewReader(`{"type": "article.saved", "resource": {"kind": "comment", "key": 1}, "data": {}}`)
+
+		resp, err := http.DefaultClient.Do(s.MustNewRequest(t, context.Background(), "generic", "POST", endpoint, body))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Status code":    {resp.StatusCode, http.StatusBadRequest},
+			"Site.BuildSite": {MockSiteCounters["BuildSite"], 0},
+		})
+	})
+
+	t.Run("NoSettings", func(t *testing.T) {
+		body := strings.NewReader(`{"type": "article.saved"}`)
+
+		resp, err := http.DefaultClient.Do(s.MustNewRequest(t, context.Background(), "test", "POST", endpoint, body))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Status code":    {resp.StatusCode, http.StatusInternalServerError},
+			"Site.BuildSite": {MockSiteCounters["BuildSite"], 0},
+		})
+	})
+
+	resetCounters()
+}
@@ -46,6 +46,23 @@ var codes = map[string]int{
 	midas.ErrInternal:     http.StatusInternalServerError,
 	midas.ErrRegistry:     http.StatusInternalServerError,
 	midas.ErrSiteConfig:   http.StatusInternalServerError,
+	midas.ErrDraftsBuild:  http.StatusInternalServerError,
+	midas.ErrDeployment:   http.StatusBadGateway,
+	midas.ErrNotFound:     http.StatusNotFound,
+}
+
+// queryFlag reads a boolean flag from the request query. Flag passed without a value (i.e. ?dryRun) is enabled.
+func queryFlag(r *http.Request, key string, defaultValue bool) bool {
+	if !r.URL.Query().Has(key) {
+		return defaultValue
+	}
+
+	switch r.URL.Query().Get(key) {
+	case "0", "false", "disable":
+		return false
+	}
+
+	return true
 }
 
 func ErrorStatusCode(code string) int {
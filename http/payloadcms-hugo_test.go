@@ -0,0 +1,92 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"github.com/kovansky/midas/testing_utils"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestServer_HandleWebhook_PayloadCms(t *testing.T) {
+	endpoint := "/payloadcms/hugo"
+
+	s := SetUp(t)
+	defer MustCloseServer(t, s)
+
+	// request sends the recorded sample payload.
+	request := func(t *testing.T, sample string) *http.Response {
+		body, err := os.ReadFile(filepath.Join("..", "payloadcms", "testdata", sample+".json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		resp, err := http.DefaultClient.Do(s.MustNewRequest(t, context.Background(), "headless", "POST", endpoint, bytes.NewReader(body)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return resp
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		resp := request(t, "create")
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Status code":      {resp.StatusCode, http.StatusNoContent},
+			"Site.CreateEntry": {MockSiteCounters["CreateEntry"], 1},
+			"Site.BuildSite":   {MockSiteCounters["BuildSite"], 1},
+		})
+	})
+
+	resetCounters()
+
+	t.Run("Global", func(t *testing.T) {
+		resp := request(t, "global")
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Status code":       {resp.StatusCode, http.StatusNoContent},
+			"Site.UpdateSingle": {MockSiteCounters["UpdateSingle"], 1},
+			"Site.BuildSite":    {MockSiteCounters["BuildSite"], 1},
+		})
+	})
+
+	resetCounters()
+
+	t.Run("Delete", func(t *testing.T) {
+		resp := request(t, "delete")
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Status code":      {resp.StatusCode, http.StatusNoContent},
+			"Site.DeleteEntry": {MockSiteCounters["DeleteEntry"], 1},
+			"Site.BuildSite":   {MockSiteCounters["BuildSite"], 1},
+		})
+	})
+
+	resetCounters()
+
+	t.Run("UnsupportedHook", func(t *testing.T) {
+		body := strings.NewReader(`{"event": "beforeChange", "operation": "create", "collection": "posts", "doc": {}}`)
+
+		resp, err := http.DefaultClient.Do(s.MustNewRequest(t, context.Background(), "headless", "POST", endpoint, body))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Status code":    {resp.StatusCode, http.StatusBadRequest},
+			"Site.BuildSite": {MockSiteCounters["BuildSite"], 0},
+		})
+	})
+
+	resetCounters()
+}
@@ -35,6 +35,8 @@ type Server struct {
 	Config midas.Config
 
 	SiteServices map[string]func(site midas.Site) (midas.SiteService, error)
+	// Providers are the CMS webhook providers, by the name used in the routes (i.e. /strapi/hugo).
+	Providers map[string]midas.Provider
 }
 
 func NewServer(logLevel string, testing bool) *Server {
@@ -65,8 +67,8 @@ func NewServer(logLevel string, testing bool) *Server {
 		router.Use(s.authenticate)
 
 		// Register specific routes
-		s.registerStrapiToHugoRoutes(router)
-		s.registerStrapiToAstroRoutes(router)
+		s.registerSiteRoutes(router)
+		s.registerWebhookRoutes(router)
 	})
 
 	return s
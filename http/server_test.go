@@ -9,11 +9,20 @@ package http_test
 import (
 	"context"
 	"github.com/kovansky/midas"
+	"github.com/kovansky/midas/concurrent"
+	"github.com/kovansky/midas/contentful"
+	"github.com/kovansky/midas/directus"
+	"github.com/kovansky/midas/generic"
 	midashttp "github.com/kovansky/midas/http"
 	"github.com/kovansky/midas/mock"
+	"github.com/kovansky/midas/payloadcms"
+	"github.com/kovansky/midas/strapi"
 	"github.com/rs/zerolog"
 	"io"
 	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -25,6 +34,11 @@ var (
 		"UpdateEntry":        0,
 		"DeleteEntry":        0,
 		"UpdateSingle":       0,
+		"DeleteSingle":       0,
+		"MirrorMedia":        0,
+		"Commit":             0,
+		"Rollback":           0,
+		"Sync":               0,
 	}
 	MockRegistryCounters = map[string]int{
 		"OpenStorage":   0,
@@ -36,6 +50,14 @@ var (
 		"ReadEntry":     0,
 		"UpdateEntry":   0,
 		"DeleteEntry":   0,
+		"Snapshot":      0,
+		"SetDependency": 0,
+	}
+	// MockLastPayload is the last payload passed to the site service update.
+	MockLastPayload        midas.Payload
+	MockDeploymentCounters = map[string]int{
+		"Deploy": 0,
+		"Plan":   0,
 	}
 )
 
@@ -47,6 +69,10 @@ func resetCounters() {
 	for key := range MockRegistryCounters {
 		MockRegistryCounters[key] = 0
 	}
+
+	for key := range MockDeploymentCounters {
+		MockDeploymentCounters[key] = 0
+	}
 }
 
 // Server represents a test wrapper for midashttp.Server.
@@ -66,11 +92,22 @@ func MustOpenServer(tb testing.TB, siteServices map[string]func(site midas.Site)
 	midas.RegistryServices = map[string]func(site midas.Site) midas.RegistryService{
 		"mock": prepareMockRegistryService,
 	}
+	midas.Concurrents = concurrent.NewList()
+	midas.DeploymentTargets = map[string]func(site midas.Site, settings midas.DeploymentSettings, isDraft bool) (midas.Deployment, error){
+		"mock": prepareMockDeployment,
+	}
 
 	// Init wrapper and set test config settings.
 	s := &Server{Server: midashttp.NewServer("trace", true)}
 
 	s.SiteServices = siteServices
+	s.Providers = map[string]midas.Provider{
+		"strapi":     strapi.NewProvider(),
+		"contentful": contentful.NewProvider(),
+		"directus":   directus.NewProvider(),
+		"generic":    generic.NewProvider(),
+		"payloadcms": payloadcms.NewProvider(),
+	}
 	s.Config = config
 
 	if err := s.Open(); err != nil {
@@ -107,6 +144,37 @@ func (s *Server) MustNewRequest(tb testing.TB, _ context.Context, apiKey, method
 }
 
 func SetUp(t *testing.T) *Server {
+	optional := false
+
+	snapshotsRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(snapshotsRoot, "public"), 0775); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(snapshotsRoot, "public", "index.html"), []byte("home"), 0664); err != nil {
+		t.Fatal(err)
+	}
+
+	mediaRoot := t.TempDir()
+
+	// CMS stand-in, serving the only Strapi post, the uploaded media and the only Directus post.
+	strapiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		post := `{"id": 5, "attributes": {"Title": "Post", "author": {"data": {"id": 3, "attributes": {"name": "Jane"}}}}}`
+
+		switch r.URL.Path {
+		case "/api/posts/5":
+			_, _ = io.WriteString(w, `{"data": `+post+`}`)
+		case "/api/posts":
+			_, _ = io.WriteString(w, `{"data": [`+post+`], "meta": {"pagination": {"page": 1, "pageCount": 1}}}`)
+		case "/items/posts/5":
+			_, _ = io.WriteString(w, `{"data": {"id": 5, "status": "published", "title": "Fetched post"}}`)
+		case "/uploads/photo.png", "/uploads/thumbnail_photo.png":
+			_, _ = io.WriteString(w, "image")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(strapiServer.Close)
+
 	s := MustOpenServer(t, map[string]func(site midas.Site) (midas.SiteService, error){
 		"hugo": func(site midas.Site) (midas.SiteService, error) {
 			siteService := mock.NewSiteService()
@@ -114,6 +182,12 @@ func SetUp(t *testing.T) *Server {
 			siteService.BuildSiteFn = func(useCache bool, _ zerolog.Logger) error {
 				MockSiteCounters["BuildSite"]++
 
+				if site.SiteName == "failedBuild" {
+					return midas.Errorf(midas.ErrInternal, "hugo build errored")
+				} else if site.SiteName == "failedDrafts" {
+					return midas.Errorf(midas.ErrDraftsBuild, "hugo draft build errored")
+				}
+
 				return nil
 			}
 			siteService.CreateEntryFn = func(_ midas.Payload) (string, error) {
@@ -121,8 +195,9 @@ func SetUp(t *testing.T) *Server {
 
 				return "", nil
 			}
-			siteService.UpdateEntryFn = func(_ midas.Payload) (string, error) {
+			siteService.UpdateEntryFn = func(payload midas.Payload) (string, error) {
 				MockSiteCounters["UpdateEntry"]++
+				MockLastPayload = payload
 
 				return "", nil
 			}
@@ -136,11 +211,36 @@ func SetUp(t *testing.T) *Server {
 
 				return prepareMockRegistryService(site), nil
 			}
+			siteService.MirrorMediaFn = func(payload midas.MediaPayload) ([]string, error) {
+				MockSiteCounters["MirrorMedia"]++
+
+				return payload.Mirror(site, func(_ string) error { return nil })
+			}
 			siteService.UpdateSingleFn = func(_ midas.Payload) (string, error) {
 				MockSiteCounters["UpdateSingle"]++
 
 				return "", nil
 			}
+			siteService.DeleteSingleFn = func(_ midas.Payload) (string, error) {
+				MockSiteCounters["DeleteSingle"]++
+
+				return "", nil
+			}
+			siteService.CommitFn = func() error {
+				MockSiteCounters["Commit"]++
+
+				return nil
+			}
+			siteService.RollbackFn = func() error {
+				MockSiteCounters["Rollback"]++
+
+				return nil
+			}
+			siteService.SyncFn = func(payloads []midas.Payload) (midas.SyncResult, error) {
+				MockSiteCounters["Sync"]++
+
+				return midas.SyncResult{Updated: len(payloads), Removed: []string{}}, nil
+			}
 
 			return siteService, nil
 		},
@@ -161,6 +261,98 @@ func SetUp(t *testing.T) *Server {
 			"otherService": {
 				Service: "other",
 			},
+			"deployments": {
+				Service:  "hugo",
+				Registry: midas.RegistrySettings{Type: "mock"},
+				Deployments: []midas.DeploymentSettings{
+					{Name: "s3", Enabled: true, Target: "mock"},
+					{Name: "error", Enabled: true, Target: "mock", Required: &optional},
+				},
+			},
+			"snapshots": {
+				SiteName:  "Snapshots",
+				Service:   "hugo",
+				RootDir:   snapshotsRoot,
+				Registry:  midas.RegistrySettings{Type: "mock"},
+				Snapshots: midas.SnapshotSettings{Enabled: true},
+				Deployments: []midas.DeploymentSettings{
+					{Name: "s3", Enabled: true, Target: "mock"},
+				},
+			},
+			"failedBuild": {
+				SiteName: "failedBuild",
+				Service:  "hugo",
+				Registry: midas.RegistrySettings{Type: "mock"},
+				CollectionTypes: map[string]midas.ModelSettings{
+					"post": {ArchetypePath: "./archetypes/archetype.md", OutputDir: "./out"},
+				},
+			},
+			"failedDrafts": {
+				SiteName:    "failedDrafts",
+				Service:     "hugo",
+				BuildDrafts: true,
+				Registry:    midas.RegistrySettings{Type: "mock"},
+				CollectionTypes: map[string]midas.ModelSettings{
+					"post": {ArchetypePath: "./archetypes/archetype.md", OutputDir: "./out"},
+				},
+			},
+			"cascade": {
+				Service:  "hugo",
+				Registry: midas.RegistrySettings{Type: "mock"},
+				Strapi:   midas.StrapiSettings{Url: strapiServer.URL},
+				CollectionTypes: map[string]midas.ModelSettings{
+					"post": {OutputDir: "./out", Relations: map[string]string{"author": "author"}},
+				},
+			},
+			"contentful": {
+				Service:  "hugo",
+				Registry: midas.RegistrySettings{Type: "mock"},
+				Locales:  midas.LocaleSettings{Default: "en-US", Mapping: map[string]string{"en-US": "en", "de-DE": "de"}},
+				CollectionTypes: map[string]midas.ModelSettings{
+					"post": {OutputDir: "./out"},
+				},
+			},
+			"headless": {
+				Service:  "hugo",
+				Registry: midas.RegistrySettings{Type: "mock"},
+				Directus: midas.DirectusSettings{Url: strapiServer.URL},
+				CollectionTypes: map[string]midas.ModelSettings{
+					"posts": {OutputDir: "./out"},
+				},
+				SingleTypes: map[string]midas.ModelSettings{
+					"header": {},
+				},
+			},
+			"generic": {
+				Service:  "hugo",
+				Registry: midas.RegistrySettings{Type: "mock"},
+				Generic: midas.GenericSettings{
+					Event:     "$.type",
+					Events:    map[string]string{"article.created": "create", "article.saved": "update", "article.removed": "delete"},
+					Model:     "$.resource.kind",
+					Id:        "$.resource.key",
+					Published: "$.resource.state.publishedAt",
+					Entry:     "$.data",
+				},
+				CollectionTypes: map[string]midas.ModelSettings{
+					"article": {},
+				},
+			},
+			"media": {
+				Service:  "hugo",
+				RootDir:  mediaRoot,
+				Registry: midas.RegistrySettings{Type: "mock"},
+				MediaUrl: strapiServer.URL,
+				MediaDir: "static/uploads",
+			},
+			"failedDeployments": {
+				Service:  "hugo",
+				Registry: midas.RegistrySettings{Type: "mock"},
+				Deployments: []midas.DeploymentSettings{
+					{Name: "s3", Enabled: true, Target: "mock"},
+					{Name: "error", Enabled: true, Target: "mock"},
+				},
+			},
 		},
 	})
 
@@ -215,6 +407,17 @@ func prepareMockRegistryService(site midas.Site) midas.RegistryService {
 
 		return nil
 	}
+	registryService.SnapshotFn = func() (midas.Registry, error) {
+		MockRegistryCounters["Snapshot"]++
+
+		return midas.Registry{
+			"1": "first.html",
+			// Contentful post, generated on a single language site and a multilingual site.
+			"post-5KsDBWseXY6QegucYAoacS":       "hello-world.md",
+			"post-5KsDBWseXY6QegucYAoacS-de-DE": "hallo-welt.de.md",
+			"post-5KsDBWseXY6QegucYAoacS-en-US": "hello-world.md",
+		}, nil
+	}
 	registryService.DeleteEntryFn = func(id string) error {
 		MockRegistryCounters["DeleteEntry"]++
 
@@ -224,6 +427,45 @@ func prepareMockRegistryService(site midas.Site) midas.RegistryService {
 
 		return nil
 	}
+	registryService.SetDependencyFn = func(_ string, _ midas.Dependency) error {
+		MockRegistryCounters["SetDependency"]++
+
+		return nil
+	}
+	registryService.ReadDependencyFn = func(id string) (midas.Dependency, error) {
+		return midas.Dependency{}, midas.Errorf(midas.ErrRegistry, "dependency of %s doesn't exist", id)
+	}
+	registryService.ReadDependentsFn = func(reference string) (midas.Dependencies, error) {
+		// The posts embed the author.
+		if reference == "author-3" {
+			return midas.Dependencies{
+				"post-5": {Model: "post", Id: "5", References: []string{"author-3"}},
+			}, nil
+		}
+
+		return midas.Dependencies{}, nil
+	}
 
 	return registryService
 }
+
+func prepareMockDeployment(site midas.Site, settings midas.DeploymentSettings, isDraft bool) (midas.Deployment, error) {
+	deployment := mock.NewDeployment(site, settings, isDraft)
+
+	deployment.DeployFn = func() error {
+		MockDeploymentCounters["Deploy"]++
+
+		if settings.Name == "error" {
+			return midas.Errorf(midas.ErrInvalid, "upload failed")
+		}
+
+		return nil
+	}
+	deployment.PlanFn = func() (*midas.DeploymentPlan, error) {
+		MockDeploymentCounters["Plan"]++
+
+		return &midas.DeploymentPlan{Upload: []string{"index.html"}, Update: []string{}, Delete: []string{}}, nil
+	}
+
+	return deployment, nil
+}
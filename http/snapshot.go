@@ -0,0 +1,158 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/httplog"
+	"github.com/kovansky/midas"
+	"github.com/kovansky/midas/concurrent"
+	"github.com/kovansky/midas/deploy"
+	"github.com/kovansky/midas/snapshot"
+	"github.com/rs/zerolog"
+	"net/http"
+	"os"
+)
+
+func (s *Server) registerSiteRoutes(r chi.Router) {
+	r.Get("/sites/{name}/snapshots", s.HandleSnapshots)
+	r.Post("/sites/{name}/rollback/{buildId}", s.HandleRollback)
+}
+
+// HandleSnapshots lists the build snapshots of the site.
+func (s *Server) HandleSnapshots(w http.ResponseWriter, r *http.Request) {
+	cfg, err := siteFromRequest(r)
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	snapshots, err := snapshot.NewService(*cfg).List()
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	jsoned, _ := json.Marshal(&SnapshotsResponse{Snapshots: snapshots})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(jsoned)
+}
+
+// HandleRollback deploys the chosen build snapshot to all deployment targets of the site, without building the site.
+// The content and registry of the site are kept, as they're not archived. The rollback is registered in
+// midas.Concurrents, so it cancels the running build of the site, and is cancelled by a newer one.
+func (s *Server) HandleRollback(w http.ResponseWriter, r *http.Request) {
+	log := httplog.LogEntry(r.Context())
+
+	cfg, err := siteFromRequest(r)
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	buildId := chi.URLParam(r, "buildId")
+	log.Info().Msgf("Received rollback request of %s to %s", cfg.SiteName, buildId)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err = midas.Concurrents.Add(concurrent.New(*cfg, cancel)); err != nil && midas.ErrorCode(err) != midas.ErrProcessNotFound {
+		Error(w, r, err)
+		return
+	}
+
+	// The process is replaced by a newer build of the site, so it's removed from the list only if not cancelled.
+	defer func() {
+		if ctx.Err() == nil {
+			midas.Concurrents.Remove(cfg.SiteName)
+		}
+	}()
+
+	dest, err := os.MkdirTemp("", "midas-rollback-")
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+	defer func() {
+		_ = os.RemoveAll(dest)
+	}()
+
+	site, err := snapshot.NewService(*cfg).Extract(buildId, dest)
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	// The build started in the meantime supersedes the rollback. Deployments can't be cancelled once started.
+	if ctx.Err() != nil {
+		Error(w, r, midas.Errorf(midas.ErrCancelled, "rollback cancelled by a newer build"))
+		return
+	}
+
+	results, err := deploy.Run(site, log)
+	if err != nil {
+		DeploymentsError(w, r, err, results)
+		return
+	}
+
+	Deployments(w, http.StatusNoContent, results)
+}
+
+type SnapshotsResponse struct {
+	Snapshots []snapshot.Snapshot `json:"snapshots"`
+}
+
+// siteFromRequest returns the site config, checking that the site from the URL is the authenticated one. The site can
+// be identified by its name or API key.
+func siteFromRequest(r *http.Request) (*midas.Site, error) {
+	cfg := midas.SiteConfigFromContext(r.Context())
+	if cfg == nil {
+		return nil, midas.Errorf(midas.ErrInternal, "site config not passed to the handler")
+	}
+
+	name := chi.URLParam(r, "name")
+	if name != cfg.SiteName && name != midas.ApiKeyFromContext(r.Context()) {
+		return nil, midas.Errorf(midas.ErrUnauthorized, "API key is not valid for site %s.", name)
+	}
+
+	return cfg, nil
+}
+
+// archiveBuild saves the snapshot of a successful build, if snapshots are enabled for the site. Failure is only
+// reported, as the build itself succeeded.
+func archiveBuild(r *http.Request, siteService midas.SiteService, log zerolog.Logger) {
+	cfg := midas.SiteConfigFromContext(r.Context())
+	if !cfg.Snapshots.Enabled {
+		return
+	}
+
+	id, err := func() (string, error) {
+		registryService, err := siteService.GetRegistryService()
+		if err != nil {
+			return "", err
+		}
+
+		registry, err := registryService.Snapshot()
+		if err != nil {
+			return "", err
+		}
+
+		return snapshot.NewService(*cfg).Create(registry)
+	}()
+	if err != nil {
+		midas.ReportError(r.Context(), err, r)
+		log.Error().Err(err).Msg("Could not archive the build")
+
+		return
+	}
+
+	log.Info().Msgf("Build archived as snapshot %s", id)
+}
@@ -0,0 +1,100 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package http_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/kovansky/midas"
+	midashttp "github.com/kovansky/midas/http"
+	"github.com/kovansky/midas/testing_utils"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestServer_HandleRollback(t *testing.T) {
+	s := SetUp(t)
+	defer MustCloseServer(t, s)
+
+	var buildId string
+
+	t.Run("ArchiveBuild", func(t *testing.T) {
+		resp, err := http.DefaultClient.Do(s.MustNewRequest(t, context.Background(), "snapshots", "POST", "/strapi/hugo/rebuild", bytes.NewReader([]byte(``))))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Status code":       {resp.StatusCode, http.StatusOK},
+			"Registry.Snapshot": {MockRegistryCounters["Snapshot"], 1},
+		})
+	})
+
+	resetCounters()
+
+	t.Run("Snapshots", func(t *testing.T) {
+		resp, err := http.DefaultClient.Do(s.MustNewRequest(t, context.Background(), "snapshots", "GET", "/sites/Snapshots/snapshots", nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		jsonBody, _ := io.ReadAll(resp.Body)
+		var respBody midashttp.SnapshotsResponse
+		err = json.Unmarshal(jsonBody, &respBody)
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Response body json unmarshal error": {err, nil},
+			"Status code":                        {resp.StatusCode, http.StatusOK},
+			"Snapshots count":                    {len(respBody.Snapshots), 1},
+		})
+
+		buildId = respBody.Snapshots[0].Id
+	})
+
+	t.Run("Rollback", func(t *testing.T) {
+		resp, err := http.DefaultClient.Do(s.MustNewRequest(t, context.Background(), "snapshots", "POST", "/sites/Snapshots/rollback/"+buildId, nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		jsonBody, _ := io.ReadAll(resp.Body)
+		var respBody midashttp.DeploymentsResponse
+		err = json.Unmarshal(jsonBody, &respBody)
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Response body json unmarshal error": {err, nil},
+			"Status code":                        {resp.StatusCode, http.StatusOK},
+			"Deployments count":                  {len(respBody.Deployments), 1},
+			"Deployment.Deploy":                  {MockDeploymentCounters["Deploy"], 1},
+			"Site.BuildSite":                     {MockSiteCounters["BuildSite"], 0},
+			"Registry.Flush":                     {MockRegistryCounters["Flush"], 0},
+			"Concurrent removed":                 {midas.Concurrents.Has("Snapshots"), false},
+		})
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		resp, err := http.DefaultClient.Do(s.MustNewRequest(t, context.Background(), "snapshots", "POST", "/sites/Snapshots/rollback/20000101T000000Z", nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testing_utils.AssertEquals(t, resp.StatusCode, http.StatusNotFound, "Status code")
+	})
+
+	t.Run("SiteMismatch", func(t *testing.T) {
+		resp, err := http.DefaultClient.Do(s.MustNewRequest(t, context.Background(), "test", "POST", "/sites/Snapshots/rollback/"+buildId, nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testing_utils.AssertEquals(t, resp.StatusCode, http.StatusUnauthorized, "Status code")
+	})
+
+	resetCounters()
+}
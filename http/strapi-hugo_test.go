@@ -10,15 +10,18 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"github.com/kovansky/midas"
 	midashttp "github.com/kovansky/midas/http"
 	"github.com/kovansky/midas/testing_utils"
 	"io"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
 
-func TestStrapiToHugoHandler_Handle(t *testing.T) {
+func TestServer_HandleWebhook_Strapi(t *testing.T) {
 	endpoint := "/strapi/hugo"
 
 	s := SetUp(t)
@@ -79,6 +82,41 @@ func TestStrapiToHugoHandler_Handle(t *testing.T) {
 		})
 	})
 
+	t.Run("UnsupportedProvider", func(t *testing.T) {
+		resp, err := http.DefaultClient.Do(s.MustNewRequest(t, context.Background(), "test", "POST", "/wordpress/hugo", bytes.NewReader([]byte(""))))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		jsonBody, _ := io.ReadAll(resp.Body)
+		var respError midashttp.ErrorResponse
+		err = json.Unmarshal(jsonBody, &respError)
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Status code":                        {resp.StatusCode, http.StatusNotFound},
+			"Response body json unmarshal error": {err, nil},
+			"Error response":                     {respError.Error, "provider wordpress is not supported"},
+		})
+	})
+
+	t.Run("UnsupportedGenerator", func(t *testing.T) {
+		resp, err := http.DefaultClient.Do(s.MustNewRequest(t, context.Background(), "test", "POST", "/strapi/jekyll", bytes.NewReader([]byte(""))))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		jsonBody, _ := io.ReadAll(resp.Body)
+		var respError midashttp.ErrorResponse
+		err = json.Unmarshal(jsonBody, &respError)
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Status code":                        {resp.StatusCode, http.StatusNotFound},
+			"Response body json unmarshal error": {err, nil},
+			"Error response":                     {respError.Error, "static site generator jekyll is not supported"},
+			"Site.CreateEntry":                   {MockSiteCounters["CreateEntry"], 0},
+		})
+	})
+
 	t.Run("UnsupportedModel", func(t *testing.T) {
 		jsonPayload := []byte(`{
     "event": "entry.create",
@@ -224,7 +262,126 @@ func TestStrapiToHugoHandler_Handle(t *testing.T) {
 
 	resetCounters()
 
+	t.Run("V5Draft", func(t *testing.T) {
+		jsonPayload := []byte(`{
+    "event": "entry.update",
+    "createdAt": "2024-01-01T10:10:10.000Z",
+    "model": "post",
+    "entry": {
+      "id": 2,
+      "documentId": "abc123",
+      "Title": "Test",
+      "createdAt": "2024-01-01T10:10:10.000Z",
+      "updatedAt": "2024-01-01T10:10:10.000Z",
+      "publishedAt": null
+    }
+  }`)
+
+		resp, err := http.DefaultClient.Do(s.MustNewRequest(t, context.Background(), "test", "POST", endpoint, bytes.NewReader(jsonPayload)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Status code":      {resp.StatusCode, http.StatusNoContent},
+			"Site.UpdateEntry": {MockSiteCounters["UpdateEntry"], 0},
+			"Site.BuildSite":   {MockSiteCounters["BuildSite"], 0},
+		})
+	})
+
+	resetCounters()
+
+	t.Run("Publish", func(t *testing.T) {
+		jsonPayload := []byte(`{
+    "event": "entry.publish",
+    "createdAt": "2022-01-01T10:10:10.000Z",
+    "model": "post",
+    "entry": {
+      "id": 1,
+      "Title": "Test",
+      "Content": "Test",
+      "createdAt": "2022-01-01T10:10:10.000Z",
+      "updatedAt": "2022-01-01T10:10:10.000Z",
+      "publishedAt": "2022-01-01T10:10:10.000Z"
+    }
+  }`)
+
+		resp, err := http.DefaultClient.Do(s.MustNewRequest(t, context.Background(), "test", "POST", endpoint, bytes.NewReader(jsonPayload)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Status code":      {resp.StatusCode, http.StatusNoContent},
+			"Site.UpdateEntry": {MockSiteCounters["UpdateEntry"], 1},
+			"Site.BuildSite":   {MockSiteCounters["BuildSite"], 1},
+			"Draft metadata":   {MockLastPayload.Metadata()["draft"], false},
+		})
+	})
+
+	resetCounters()
+
+	t.Run("Unpublish", func(t *testing.T) {
+		jsonPayload := []byte(`{
+    "event": "entry.unpublish",
+    "createdAt": "2022-01-01T10:10:10.000Z",
+    "model": "post",
+    "entry": {
+      "id": 1,
+      "Title": "Test",
+      "Content": "Test",
+      "createdAt": "2022-01-01T10:10:10.000Z",
+      "updatedAt": "2022-01-01T10:10:10.000Z",
+      "publishedAt": null
+    }
+  }`)
+
+		resp, err := http.DefaultClient.Do(s.MustNewRequest(t, context.Background(), "test", "POST", endpoint, bytes.NewReader(jsonPayload)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Status code":        {resp.StatusCode, http.StatusNoContent},
+			"Site.UpdateEntry":   {MockSiteCounters["UpdateEntry"], 1},
+			"Site.BuildSite":     {MockSiteCounters["BuildSite"], 1},
+			"Draft metadata":     {MockLastPayload.Metadata()["draft"], true},
+			"Published metadata": {MockLastPayload.Metadata()["published"], false},
+		})
+	})
+
+	resetCounters()
+
 	t.Run("Delete", func(t *testing.T) {
+		t.Run("Single", func(t *testing.T) {
+			jsonPayload := []byte(`{
+    "event": "entry.delete",
+    "createdAt": "2022-01-01T10:10:10.000Z",
+    "model": "homepage",
+    "entry": {
+      "id": 1,
+      "Title": "Test",
+      "Content": "Test",
+      "createdAt": "2022-01-01T10:10:10.000Z",
+      "updatedAt": "2022-01-01T10:10:10.000Z",
+      "publishedAt": null
+    }
+  }`)
+
+			resp, err := http.DefaultClient.Do(s.MustNewRequest(t, context.Background(), "test", "POST", endpoint, bytes.NewReader(jsonPayload)))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testing_utils.AssertTable(t, map[string][]interface{}{
+				"Status code":       {resp.StatusCode, http.StatusNoContent},
+				"Site.DeleteSingle": {MockSiteCounters["DeleteSingle"], 1},
+				"Site.BuildSite":    {MockSiteCounters["BuildSite"], 1},
+			})
+		})
+
+		resetCounters()
+
 		t.Run("Collection", func(t *testing.T) {
 			jsonPayload := []byte(`{
     "event": "entry.delete",
@@ -249,6 +406,170 @@ func TestStrapiToHugoHandler_Handle(t *testing.T) {
 				"Status code":      {resp.StatusCode, http.StatusNoContent},
 				"Site.UpdateEntry": {MockSiteCounters["DeleteEntry"], 1},
 				"Site.BuildSite":   {MockSiteCounters["BuildSite"], 1},
+				"Site.Commit":      {MockSiteCounters["Commit"], 1},
+				"Site.Rollback":    {MockSiteCounters["Rollback"], 0},
+			})
+		})
+	})
+
+	resetCounters()
+
+	t.Run("FailedBuild", func(t *testing.T) {
+		jsonPayload := []byte(`{
+    "event": "entry.update",
+    "createdAt": "2022-01-01T10:10:10.000Z",
+    "model": "post",
+    "entry": {
+      "id": 1,
+      "Title": "Test",
+      "Content": "Test",
+      "createdAt": "2022-01-01T10:10:10.000Z",
+      "updatedAt": "2022-01-01T10:10:10.000Z",
+      "publishedAt": null
+    }
+  }`)
+
+		resp, err := http.DefaultClient.Do(s.MustNewRequest(t, context.Background(), "failedBuild", "POST", endpoint, bytes.NewReader(jsonPayload)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Status code":       {resp.StatusCode, http.StatusInternalServerError},
+			"Site.UpdateEntry":  {MockSiteCounters["UpdateEntry"], 1},
+			"Site.Rollback":     {MockSiteCounters["Rollback"], 1},
+			"Site.Commit":       {MockSiteCounters["Commit"], 0},
+			"Deployment.Deploy": {MockDeploymentCounters["Deploy"], 0},
+		})
+	})
+
+	resetCounters()
+
+	t.Run("FailedDraftsBuild", func(t *testing.T) {
+		jsonPayload := []byte(`{
+    "event": "entry.update",
+    "createdAt": "2022-01-01T10:10:10.000Z",
+    "model": "post",
+    "entry": {
+      "id": 1,
+      "Title": "Test",
+      "Content": "Test",
+      "createdAt": "2022-01-01T10:10:10.000Z",
+      "updatedAt": "2022-01-01T10:10:10.000Z",
+      "publishedAt": null
+    }
+  }`)
+
+		resp, err := http.DefaultClient.Do(s.MustNewRequest(t, context.Background(), "failedDrafts", "POST", endpoint, bytes.NewReader(jsonPayload)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The site was built with the changes, so they're kept.
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Status code":       {resp.StatusCode, http.StatusInternalServerError},
+			"Site.UpdateEntry":  {MockSiteCounters["UpdateEntry"], 1},
+			"Site.Rollback":     {MockSiteCounters["Rollback"], 0},
+			"Site.Commit":       {MockSiteCounters["Commit"], 1},
+			"Deployment.Deploy": {MockDeploymentCounters["Deploy"], 0},
+		})
+	})
+
+	resetCounters()
+
+	t.Run("Cascade", func(t *testing.T) {
+		jsonPayload := []byte(`{
+    "event": "entry.update",
+    "createdAt": "2022-01-01T10:10:10.000Z",
+    "model": "author",
+    "entry": {
+      "id": 3,
+      "name": "Jane",
+      "createdAt": "2022-01-01T10:10:10.000Z",
+      "updatedAt": "2022-01-01T10:10:10.000Z"
+    }
+  }`)
+
+		resp, err := http.DefaultClient.Do(s.MustNewRequest(t, context.Background(), "cascade", "POST", endpoint, bytes.NewReader(jsonPayload)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		author, _ := MockLastPayload.Entry()["author"].(map[string]interface{})
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Status code":      {resp.StatusCode, http.StatusNoContent},
+			"Site.UpdateEntry": {MockSiteCounters["UpdateEntry"], 1},
+			"Site.BuildSite":   {MockSiteCounters["BuildSite"], 1},
+			"Dependent model":  {MockLastPayload.Metadata()["model"], "post"},
+			"Dependent author": {author["name"], "Jane"},
+		})
+	})
+
+	resetCounters()
+
+	t.Run("Media", func(t *testing.T) {
+		mediaPayload := func(event string) io.Reader {
+			return strings.NewReader(`{
+    "event": "` + event + `",
+    "createdAt": "2022-01-01T10:10:10.000Z",
+    "media": {
+      "id": 1,
+      "name": "photo.png",
+      "mime": "image/png",
+      "url": "/uploads/photo.png",
+      "formats": {"thumbnail": {"url": "/uploads/thumbnail_photo.png"}}
+    }
+  }`)
+		}
+		mediaDir := filepath.Join(s.Config.Sites["media"].RootDir, "static", "uploads")
+
+		t.Run("NotEnabled", func(t *testing.T) {
+			resp, err := http.DefaultClient.Do(s.MustNewRequest(t, context.Background(), "test", "POST", endpoint, mediaPayload("media.create")))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			testing_utils.AssertTable(t, map[string][]interface{}{
+				"Status code":    {resp.StatusCode, http.StatusBadRequest},
+				"Site.BuildSite": {MockSiteCounters["BuildSite"], 0},
+			})
+		})
+
+		resetCounters()
+
+		t.Run("Create", func(t *testing.T) {
+			resp, err := http.DefaultClient.Do(s.MustNewRequest(t, context.Background(), "media", "POST", endpoint, mediaPayload("media.create")))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			photo, _ := os.ReadFile(filepath.Join(mediaDir, "photo.png"))
+			thumbnail, _ := os.ReadFile(filepath.Join(mediaDir, "thumbnail_photo.png"))
+
+			testing_utils.AssertTable(t, map[string][]interface{}{
+				"Status code":      {resp.StatusCode, http.StatusNoContent},
+				"Site.MirrorMedia": {MockSiteCounters["MirrorMedia"], 1},
+				"Site.BuildSite":   {MockSiteCounters["BuildSite"], 1},
+				"Photo":            {string(photo), "image"},
+				"Thumbnail":        {string(thumbnail), "image"},
+			})
+		})
+
+		resetCounters()
+
+		t.Run("Delete", func(t *testing.T) {
+			resp, err := http.DefaultClient.Do(s.MustNewRequest(t, context.Background(), "media", "POST", endpoint, mediaPayload("media.delete")))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			entries, _ := os.ReadDir(mediaDir)
+
+			testing_utils.AssertTable(t, map[string][]interface{}{
+				"Status code":    {resp.StatusCode, http.StatusNoContent},
+				"Site.BuildSite": {MockSiteCounters["BuildSite"], 1},
+				"Files":          {len(entries), 0},
 			})
 		})
 	})
@@ -256,7 +577,7 @@ func TestStrapiToHugoHandler_Handle(t *testing.T) {
 	resetCounters()
 }
 
-func TestServer_HandleHugoRebuild(t *testing.T) {
+func TestServer_HandleRebuild(t *testing.T) {
 	endpoint := "/strapi/hugo/rebuild"
 
 	s := SetUp(t)
@@ -337,4 +658,133 @@ func TestServer_HandleHugoRebuild(t *testing.T) {
 	})
 
 	resetCounters()
+
+	t.Run("Deployments", func(t *testing.T) {
+		t.Run("OptionalFailed", func(t *testing.T) {
+			resp, err := http.DefaultClient.Do(s.MustNewRequest(t, context.Background(), "deployments", "POST", endpoint, bytes.NewReader([]byte(``))))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			jsonBody, _ := io.ReadAll(resp.Body)
+			var respBody midashttp.DeploymentsResponse
+			err = json.Unmarshal(jsonBody, &respBody)
+
+			testing_utils.AssertTable(t, map[string][]interface{}{
+				"Response body json unmarshal error": {err, nil},
+				"Status code":                        {resp.StatusCode, http.StatusOK},
+				"Response status":                    {respBody.Status, "ok"},
+				"Deployments count":                  {len(respBody.Deployments), 2},
+				"Required deployment":                {respBody.Deployments[0], midas.DeploymentResult{Name: "s3", Target: "mock", Required: true, Status: midas.DeploymentOk}},
+				"Optional deployment":                {respBody.Deployments[1], midas.DeploymentResult{Name: "error", Target: "mock", Status: midas.DeploymentFailed, Error: "upload failed"}},
+			})
+		})
+
+		t.Run("RequiredFailed", func(t *testing.T) {
+			resp, err := http.DefaultClient.Do(s.MustNewRequest(t, context.Background(), "failedDeployments", "POST", endpoint, bytes.NewReader([]byte(``))))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			jsonBody, _ := io.ReadAll(resp.Body)
+			var respError midashttp.DeploymentsErrorResponse
+			err = json.Unmarshal(jsonBody, &respError)
+
+			testing_utils.AssertTable(t, map[string][]interface{}{
+				"Response body json unmarshal error": {err, nil},
+				"Status code":                        {resp.StatusCode, http.StatusBadGateway},
+				"Error":                              {respError.Error, "required deployments failed: error"},
+				"Deployments count":                  {len(respError.Deployments), 2},
+				"Other deployment status":            {respError.Deployments[0].Status, midas.DeploymentOk},
+				"Failed deployment status":           {respError.Deployments[1].Status, midas.DeploymentFailed},
+			})
+		})
+
+		resetCounters()
+
+		t.Run("DryRun", func(t *testing.T) {
+			resp, err := http.DefaultClient.Do(s.MustNewRequest(t, context.Background(), "deployments", "POST", endpoint+"?dryRun", bytes.NewReader([]byte(``))))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			jsonBody, _ := io.ReadAll(resp.Body)
+			var respBody midashttp.DeploymentsResponse
+			err = json.Unmarshal(jsonBody, &respBody)
+
+			testing_utils.AssertTable(t, map[string][]interface{}{
+				"Response body json unmarshal error": {err, nil},
+				"Status code":                        {resp.StatusCode, http.StatusOK},
+				"Deployments count":                  {len(respBody.Deployments), 2},
+				"Planned upload":                     {respBody.Deployments[0].Plan.Upload[0], "index.html"},
+				"Deployment.Plan":                    {MockDeploymentCounters["Plan"], 2},
+				"Deployment.Deploy":                  {MockDeploymentCounters["Deploy"], 0},
+				"Site.BuildSite":                     {MockSiteCounters["BuildSite"], 0},
+			})
+		})
+	})
+
+	resetCounters()
+}
+
+func TestServer_HandleSync(t *testing.T) {
+	endpoint := "/strapi/hugo/sync"
+
+	s := SetUp(t)
+	defer MustCloseServer(t, s)
+
+	t.Run("Sync", func(t *testing.T) {
+		resp, err := http.DefaultClient.Do(s.MustNewRequest(t, context.Background(), "cascade", "POST", endpoint, bytes.NewReader([]byte(""))))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		jsonBody, _ := io.ReadAll(resp.Body)
+		var respSync midashttp.SyncResponse
+		err = json.Unmarshal(jsonBody, &respSync)
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Status code":                        {resp.StatusCode, http.StatusOK},
+			"Response body json unmarshal error": {err, nil},
+			"Updated":                            {respSync.Sync.Updated, 1},
+			"Site.Sync":                          {MockSiteCounters["Sync"], 1},
+			"Site.BuildSite":                     {MockSiteCounters["BuildSite"], 1},
+			"Site.Commit":                        {MockSiteCounters["Commit"], 1},
+		})
+	})
+
+	resetCounters()
+
+	t.Run("NoStrapi", func(t *testing.T) {
+		resp, err := http.DefaultClient.Do(s.MustNewRequest(t, context.Background(), "test", "POST", endpoint, bytes.NewReader([]byte(""))))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Status code":    {resp.StatusCode, http.StatusInternalServerError},
+			"Site.Sync":      {MockSiteCounters["Sync"], 0},
+			"Site.BuildSite": {MockSiteCounters["BuildSite"], 0},
+		})
+	})
+
+	t.Run("Unsupported", func(t *testing.T) {
+		resp, err := http.DefaultClient.Do(s.MustNewRequest(t, context.Background(), "contentful", "POST", "/contentful/hugo/sync", bytes.NewReader([]byte(""))))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		jsonBody, _ := io.ReadAll(resp.Body)
+		var respError midashttp.ErrorResponse
+		err = json.Unmarshal(jsonBody, &respError)
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Status code":                        {resp.StatusCode, http.StatusNotFound},
+			"Response body json unmarshal error": {err, nil},
+			"Error response":                     {respError.Error, "provider contentful doesn't support sync"},
+			"Site.Sync":                          {MockSiteCounters["Sync"], 0},
+		})
+	})
+
+	resetCounters()
 }
@@ -0,0 +1,338 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/httplog"
+	"github.com/kovansky/midas"
+	"github.com/kovansky/midas/deploy"
+	"github.com/rs/zerolog"
+	"io"
+	"net/http"
+)
+
+// SyncResponse is returned by the content resync endpoint.
+type SyncResponse struct {
+	Status      string                   `json:"status"`
+	Sync        midas.SyncResult         `json:"sync"`
+	Deployments []midas.DeploymentResult `json:"deployments,omitempty"`
+}
+
+// registerWebhookRoutes registers the webhook routes of every provider (CMS) and static site generator pair, i.e.
+// /strapi/hugo.
+func (s *Server) registerWebhookRoutes(r chi.Router) {
+	r.Post("/{provider}/{ssg}", s.handleWebhook)
+	r.Post("/{provider}/{ssg}/rebuild", s.HandleRebuild)
+	r.Post("/{provider}/{ssg}/sync", s.HandleSync)
+}
+
+// handleWebhook applies the content changes requested by the webhook of the provider, then builds and deploys the
+// site. If any change or the build fails, the changes are rolled back.
+func (s *Server) handleWebhook(w http.ResponseWriter, r *http.Request) {
+	log := httplog.LogEntry(r.Context())
+
+	log.Info().Msgf("Received request from %s to %s", chi.URLParam(r, "provider"), chi.URLParam(r, "ssg"))
+
+	provider, err := s.provider(r)
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	cfg, siteService, err := s.siteService(r)
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+	defer func() {
+		registry, _ := siteService.GetRegistryService()
+		registry.CloseStorage()
+	}()
+
+	jsonBody, err := io.ReadAll(r.Body)
+
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	changes, err := provider.Changes(*cfg, siteService, midas.Webhook{Header: r.Header, Body: jsonBody}, log)
+	var syntxErr *json.SyntaxError
+
+	if err != nil && errors.As(err, &syntxErr) {
+		Error(w, r, midas.Errorf(midas.ErrInvalid, "payload JSON malformed"))
+		return
+	} else if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	if len(changes) == 0 {
+		log.Info().Msg("The request has no content to change")
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	for _, change := range changes {
+		if err = applyChange(cfg, siteService, change, log); err != nil {
+			rollback(r, siteService, log)
+			Error(w, r, err)
+			return
+		}
+	}
+
+	if err = buildSite(r, siteService, log); err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	archiveBuild(r, siteService, log)
+
+	results, err := deploy.Run(*cfg, log)
+	if err != nil {
+		DeploymentsError(w, r, err, results)
+		return
+	}
+
+	Deployments(w, http.StatusNoContent, results)
+}
+
+// HandleRebuild builds and deploys the site without changing its content. In dry run, the deployments of the current
+// build output are only planned, without building the site (so the running build is not cancelled either).
+func (s *Server) HandleRebuild(w http.ResponseWriter, r *http.Request) {
+	log := httplog.LogEntry(r.Context())
+
+	log.Info().Msgf("Received rebuild request from %s to %s", chi.URLParam(r, "provider"), chi.URLParam(r, "ssg"))
+
+	if _, err := s.provider(r); err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	cfg, siteService, err := s.siteService(r)
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	useCache := queryFlag(r, "cache", true)
+
+	// In dry run, the deployments are only planned, without changing anything on the targets.
+	runDeploys := deploy.Plan
+	if !queryFlag(r, "dryRun", false) {
+		if err = siteService.BuildSite(useCache, log); err != nil {
+			Error(w, r, err)
+			return
+		}
+
+		archiveBuild(r, siteService, log)
+		runDeploys = deploy.Run
+	}
+
+	results, err := runDeploys(*cfg, log)
+	if err != nil {
+		DeploymentsError(w, r, err, results)
+		return
+	}
+
+	jsoned, _ := json.Marshal(&DeploymentsResponse{Status: "ok", Deployments: results})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(jsoned)
+}
+
+// HandleSync regenerates the content of the site from all entries fetched from the provider, removing the content of
+// the entries which don't exist anymore. Then the site is built and deployed once.
+func (s *Server) HandleSync(w http.ResponseWriter, r *http.Request) {
+	log := httplog.LogEntry(r.Context())
+
+	log.Info().Msgf("Received sync request from %s to %s", chi.URLParam(r, "provider"), chi.URLParam(r, "ssg"))
+
+	provider, err := s.provider(r)
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	syncProvider, ok := provider.(midas.SyncProvider)
+	if !ok {
+		Error(w, r, midas.Errorf(midas.ErrNotFound, "provider %s doesn't support sync", chi.URLParam(r, "provider")))
+		return
+	}
+
+	cfg, siteService, err := s.siteService(r)
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+	defer func() {
+		registry, _ := siteService.GetRegistryService()
+		registry.CloseStorage()
+	}()
+
+	payloads, err := syncProvider.FetchAll(*cfg)
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	result, err := siteService.Sync(payloads)
+	if err != nil {
+		rollback(r, siteService, log)
+		Error(w, r, err)
+		return
+	}
+
+	log.Info().Msgf("Synced %d entries, removed %d", result.Updated, len(result.Removed))
+
+	if err = buildSite(r, siteService, log); err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	archiveBuild(r, siteService, log)
+
+	results, err := deploy.Run(*cfg, log)
+	if err != nil {
+		DeploymentsError(w, r, err, results)
+		return
+	}
+
+	jsoned, _ := json.Marshal(&SyncResponse{Status: "ok", Sync: result, Deployments: results})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(jsoned)
+}
+
+// provider returns the provider of the request.
+func (s *Server) provider(r *http.Request) (midas.Provider, error) {
+	name := chi.URLParam(r, "provider")
+
+	provider, ok := s.Providers[name]
+	if !ok {
+		return nil, midas.Errorf(midas.ErrNotFound, "provider %s is not supported", name)
+	}
+
+	return provider, nil
+}
+
+// siteService returns the config of the requested site and its service of the static site generator of the request.
+func (s *Server) siteService(r *http.Request) (*midas.Site, midas.SiteService, error) {
+	cfg := midas.SiteConfigFromContext(r.Context())
+
+	if cfg == nil {
+		return nil, nil, midas.Errorf(midas.ErrInternal, "site config not passed to the handler")
+	}
+
+	ssg := chi.URLParam(r, "ssg")
+
+	newSiteService, ok := s.SiteServices[ssg]
+	if !ok {
+		return nil, nil, midas.Errorf(midas.ErrNotFound, "static site generator %s is not supported", ssg)
+	}
+
+	if cfg.Service != ssg {
+		return nil, nil, midas.Errorf(midas.ErrInvalid, "service mismatch: called %s while requested site is on %s", ssg, cfg.Service)
+	}
+
+	siteService, err := newSiteService(*cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return cfg, siteService, nil
+}
+
+// applyChange changes the content of the entry. Entries of the single types are changed as singles, and the media
+// changes are mirrored into the media directory.
+func applyChange(cfg *midas.Site, siteService midas.SiteService, change midas.Change, log zerolog.Logger) error {
+	if change.Action == midas.ActionMedia {
+		media, ok := change.Payload.(midas.MediaPayload)
+		if !ok {
+			return midas.Errorf(midas.ErrInternal, "media change has no media payload")
+		}
+
+		files, err := siteService.MirrorMedia(media)
+		if err != nil {
+			return err
+		}
+
+		log.Info().Msgf("Mirrored %d media files", len(files))
+
+		return nil
+	}
+
+	metadata := change.Payload.Metadata()
+	_, isSingle := cfg.SingleTypes[fmt.Sprint(metadata["model"])]
+
+	log.Info().Fields(map[string]interface{}{
+		"page":   cfg.SiteName,
+		"action": change.Action.String(),
+		"model":  metadata["model"],
+		"id":     metadata["entryId"],
+	}).Msg("Request data")
+
+	var apply func(payload midas.Payload) (string, error)
+
+	switch change.Action {
+	case midas.ActionCreate:
+		apply = siteService.CreateEntry
+		if isSingle {
+			apply = siteService.UpdateSingle
+		}
+	case midas.ActionUpdate:
+		apply = siteService.UpdateEntry
+		if isSingle {
+			apply = siteService.UpdateSingle
+		}
+	case midas.ActionDelete:
+		apply = siteService.DeleteEntry
+		if isSingle {
+			apply = siteService.DeleteSingle
+		}
+	default:
+		return midas.Errorf(midas.ErrInternal, "action %s is invalid", change.Action)
+	}
+
+	_, err := apply(change.Payload)
+
+	return err
+}
+
+// buildSite builds the site with the content changes and commits them. If the build fails, the changes are rolled
+// back, so the site content matches the last successful build. Failed drafts build doesn't roll the changes back, as
+// the site was already built with them.
+func buildSite(r *http.Request, siteService midas.SiteService, log zerolog.Logger) error {
+	err := siteService.BuildSite(true, log)
+
+	// Cancelled build is superseded by the build of a newer request, which includes the changes.
+	if code := midas.ErrorCode(err); err != nil && code != midas.ErrCancelled && code != midas.ErrDraftsBuild {
+		rollback(r, siteService, log)
+		return err
+	}
+
+	if commitErr := siteService.Commit(); commitErr != nil {
+		midas.ReportError(r.Context(), commitErr, r)
+		log.Error().Err(commitErr).Msg("Could not commit the content changes")
+	}
+
+	return err
+}
+
+// rollback restores the content changed by the request. Failure is only reported, as the request fails anyway.
+func rollback(r *http.Request, siteService midas.SiteService, log zerolog.Logger) {
+	if err := siteService.Rollback(); err != nil {
+		midas.ReportError(r.Context(), err, r)
+		log.Error().Err(err).Msg("Could not roll back the content changes")
+	}
+}
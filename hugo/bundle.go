@@ -0,0 +1,356 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package hugo
+
+import (
+	"fmt"
+	"github.com/kovansky/midas"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// mediaClient is used to download the media into bundles.
+var mediaClient = &http.Client{Timeout: time.Minute}
+
+// bundlePayload is the payload with entry, which media URLs point to the files in the bundle.
+type bundlePayload struct {
+	midas.Payload
+	entry map[string]interface{}
+}
+
+func (p bundlePayload) Entry() map[string]interface{} {
+	return p.entry
+}
+
+// writeBundle writes the entry as a leaf bundle in bundleDir: the index file and the media referenced by the entry.
+// The bundle is prepared in a temporary directory and replaces the existing one at the end, so the old bundle is kept
+// on error.
+func (l Layout) writeBundle(model *midas.ModelSettings, render func(payload midas.Payload) ([]byte, error), payload midas.Payload, bundleDir string) error {
+	tmpDir, err := os.MkdirTemp(filepath.Dir(bundleDir), ".midas-bundle-")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	downloader, err := newMediaDownloader(l.Site.MediaUrl, tmpDir)
+	if err != nil {
+		return err
+	}
+
+	entry, err := downloader.bundle(payload.Entry())
+	if err != nil {
+		return err
+	}
+
+	content, err := render(bundlePayload{Payload: payload, entry: entry})
+	if err != nil {
+		return err
+	}
+
+	if err = os.WriteFile(filepath.Join(tmpDir, "index"+outputExtension(model)), content, 0664); err != nil {
+		return err
+	}
+
+	// MkdirTemp creates the directory accessible only by the owner.
+	if err = os.Chmod(tmpDir, 0775); err != nil {
+		return err
+	}
+
+	if err = os.RemoveAll(bundleDir); err != nil {
+		return err
+	}
+
+	if err = os.Rename(tmpDir, bundleDir); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// mediaDownloader downloads the media referenced by the entry into the bundle directory.
+type mediaDownloader struct {
+	baseUrl *url.URL
+	dir     string
+
+	// uploadsUrl matches the URLs of uploaded files in text fields, i.e. rich text.
+	uploadsUrl *regexp.Regexp
+	// files maps the absolute media URLs to the file names in the bundle.
+	files map[string]string
+	// used holds the file names already taken in the bundle.
+	used map[string]bool
+}
+
+func newMediaDownloader(mediaUrl, dir string) (*mediaDownloader, error) {
+	var baseUrl *url.URL
+	uploadsUrl := `/uploads/[^\s"'()<>\[\]]+`
+
+	if mediaUrl != "" {
+		parsed, err := url.Parse(mediaUrl)
+		if err != nil {
+			return nil, midas.Errorf(midas.ErrSiteConfig, "mediaUrl %s is invalid: %s", mediaUrl, err)
+		}
+
+		baseUrl = parsed
+		uploadsUrl = `(?:` + regexp.QuoteMeta(strings.TrimRight(mediaUrl, "/")) + `)?` + uploadsUrl
+	}
+
+	return &mediaDownloader{
+		baseUrl:    baseUrl,
+		dir:        dir,
+		uploadsUrl: regexp.MustCompile(`(?:^|[\s("'=\[])(` + uploadsUrl + `)`),
+		files:      make(map[string]string),
+		used:       map[string]bool{"index.md": true, "index.html": true},
+	}, nil
+}
+
+// bundle downloads the media referenced by the entry and returns the copy of the entry with media URLs pointing to the
+// downloaded files. Media are the objects with url and mime fields (like cover images or galleries) and the URLs of
+// uploaded files in text fields. Other formats of the media (i.e. thumbnails) are not downloaded, as Hugo can process
+// the images on its own, but their URLs are made absolute.
+func (d *mediaDownloader) bundle(entry map[string]interface{}) (map[string]interface{}, error) {
+	withMedia, err := d.bundleMedia(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	bundled, err := d.bundleText(withMedia)
+	if err != nil {
+		return nil, err
+	}
+
+	return bundled.(map[string]interface{}), nil
+}
+
+// bundleMedia downloads the media objects and rewrites their URLs.
+func (d *mediaDownloader) bundleMedia(value interface{}) (interface{}, error) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(value))
+		for key, nested := range value {
+			copied[key] = nested
+		}
+
+		if isMedia(value) {
+			fileName, err := d.download(value["url"].(string))
+			if err != nil {
+				return nil, err
+			}
+
+			copied["url"] = fileName
+
+			if formats, ok := value["formats"].(map[string]interface{}); ok {
+				copied["formats"] = d.absoluteFormats(formats)
+			}
+
+			return copied, nil
+		}
+
+		for key, nested := range copied {
+			bundled, err := d.bundleMedia(nested)
+			if err != nil {
+				return nil, err
+			}
+
+			copied[key] = bundled
+		}
+
+		return copied, nil
+	case []interface{}:
+		copied := make([]interface{}, len(value))
+		for i, nested := range value {
+			bundled, err := d.bundleMedia(nested)
+			if err != nil {
+				return nil, err
+			}
+
+			copied[i] = bundled
+		}
+
+		return copied, nil
+	default:
+		return value, nil
+	}
+}
+
+// absoluteFormats returns the copy of other formats of the media, with URLs made absolute, so they still point to the
+// CMS from the bundle.
+func (d *mediaDownloader) absoluteFormats(formats map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(formats))
+
+	for name, format := range formats {
+		copied[name] = format
+
+		object, ok := format.(map[string]interface{})
+		if !ok || d.baseUrl == nil {
+			continue
+		}
+
+		formatUrl, ok := object["url"].(string)
+		if !ok {
+			continue
+		}
+
+		parsed, err := url.Parse(formatUrl)
+		if err != nil || parsed.IsAbs() {
+			continue
+		}
+
+		copiedFormat := make(map[string]interface{}, len(object))
+		for key, value := range object {
+			copiedFormat[key] = value
+		}
+		copiedFormat["url"] = d.baseUrl.ResolveReference(parsed).String()
+
+		copied[name] = copiedFormat
+	}
+
+	return copied
+}
+
+// bundleText downloads the uploaded files referenced in text fields and rewrites their URLs, as well as URLs of the
+// media already downloaded.
+func (d *mediaDownloader) bundleText(value interface{}) (interface{}, error) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		if isMedia(value) {
+			return value, nil
+		}
+
+		for key, nested := range value {
+			bundled, err := d.bundleText(nested)
+			if err != nil {
+				return nil, err
+			}
+
+			value[key] = bundled
+		}
+
+		return value, nil
+	case []interface{}:
+		for i, nested := range value {
+			bundled, err := d.bundleText(nested)
+			if err != nil {
+				return nil, err
+			}
+
+			value[i] = bundled
+		}
+
+		return value, nil
+	case string:
+		return d.rewriteText(value)
+	default:
+		return value, nil
+	}
+}
+
+func (d *mediaDownloader) rewriteText(text string) (string, error) {
+	var builder strings.Builder
+	last := 0
+
+	for _, match := range d.uploadsUrl.FindAllStringSubmatchIndex(text, -1) {
+		start, end := match[2], match[3]
+
+		fileName, err := d.download(text[start:end])
+		if err != nil {
+			return "", err
+		}
+
+		builder.WriteString(text[last:start])
+		builder.WriteString(fileName)
+		last = end
+	}
+	builder.WriteString(text[last:])
+
+	rewritten := builder.String()
+
+	// Media hosted elsewhere (i.e. on cloud storage) may be referenced in text too.
+	for mediaUrl, fileName := range d.files {
+		rewritten = strings.ReplaceAll(rewritten, mediaUrl, fileName)
+	}
+
+	return rewritten, nil
+}
+
+// isMedia tells if the object describes an uploaded media file.
+func isMedia(object map[string]interface{}) bool {
+	_, isUrl := object["url"].(string)
+	_, isMime := object["mime"].(string)
+
+	return isUrl && isMime
+}
+
+// download downloads the media into the bundle directory, unless it was already downloaded, and returns its file name.
+func (d *mediaDownloader) download(mediaUrl string) (string, error) {
+	parsed, err := url.Parse(mediaUrl)
+	if err != nil {
+		return "", midas.Errorf(midas.ErrInvalid, "media URL %s is invalid: %s", mediaUrl, err)
+	}
+
+	if !parsed.IsAbs() {
+		if d.baseUrl == nil {
+			return "", midas.Errorf(midas.ErrSiteConfig, "mediaUrl is required to download media %s", mediaUrl)
+		}
+
+		parsed = d.baseUrl.ResolveReference(parsed)
+	}
+
+	// The same media may be referenced by relative and absolute URL.
+	absoluteUrl := parsed.String()
+	if fileName, ok := d.files[absoluteUrl]; ok {
+		return fileName, nil
+	}
+
+	fileName := path.Base(parsed.Path)
+	if fileName == "." || fileName == "/" || fileName == ".." {
+		return "", midas.Errorf(midas.ErrInvalid, "media URL %s has no file name", mediaUrl)
+	}
+
+	// Different media may have the same file name, i.e. when hosted on different servers.
+	extension := path.Ext(fileName)
+	for i := 1; d.used[fileName]; i++ {
+		fileName = fmt.Sprintf("%s-%d%s", strings.TrimSuffix(path.Base(parsed.Path), extension), i, extension)
+	}
+
+	resp, err := mediaClient.Get(absoluteUrl)
+	if err != nil {
+		return "", fmt.Errorf("could not download media %s: %v", mediaUrl, err)
+	}
+	defer func(body io.ReadCloser) {
+		_ = body.Close()
+	}(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("could not download media %s: %s", mediaUrl, resp.Status)
+	}
+
+	file, err := os.Create(filepath.Join(d.dir, fileName))
+	if err != nil {
+		return "", err
+	}
+	defer func(file *os.File) {
+		_ = file.Close()
+	}(file)
+
+	if _, err = io.Copy(file, resp.Body); err != nil {
+		return "", err
+	}
+
+	d.files[absoluteUrl] = fileName
+	d.used[fileName] = true
+
+	return fileName, nil
+}
@@ -0,0 +1,236 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package hugo
+
+import (
+	"fmt"
+	"github.com/kovansky/midas"
+	"github.com/kovansky/midas/mock"
+	"github.com/kovansky/midas/strapi"
+	"github.com/kovansky/midas/testing_utils"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSiteService_Bundle(t *testing.T) {
+	downloads := map[string]int{}
+	media := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		downloads[r.URL.Path]++
+
+		if !strings.HasPrefix(r.URL.Path, "/uploads/") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, _ = w.Write([]byte("content of " + r.URL.Path))
+	}))
+	defer media.Close()
+
+	registry := midas.Registry{}
+
+	rootDir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(rootDir, "content"), 0775); err != nil {
+		t.Fatal(err)
+	}
+
+	site := newTestSite(t, midas.Site{
+		RootDir:  rootDir,
+		MediaUrl: media.URL,
+		CollectionTypes: map[string]midas.ModelSettings{
+			"post": {OutputDir: "content/posts", Output: midas.OutputMarkdown, Bundle: true},
+		},
+	}, registry)
+
+	payload := func(event, title string) midas.Payload {
+		payload, err := strapi.ParsePayload([]byte(fmt.Sprintf(`{
+    "event": "%s",
+    "createdAt": "2022-01-01T10:10:10.000Z",
+    "model": "post",
+    "entry": {
+      "id": 1,
+      "Title": "%s",
+      "Content": "Text ![inline](/uploads/inline.png) and ![external](%s/uploads/cover.jpg)",
+      "Cover": {"id": 1, "url": "/uploads/cover.jpg", "mime": "image/jpeg", "formats": {"thumbnail": {"url": "/uploads/thumbnail_cover.jpg", "mime": "image/jpeg"}}},
+      "Gallery": [{"id": 2, "url": "%s/uploads/photo.jpg", "mime": "image/jpeg"}],
+      "createdAt": "2022-01-01T10:10:10.000Z",
+      "updatedAt": "2022-01-01T10:10:10.000Z",
+      "publishedAt": "2022-01-01T10:10:10.000Z"
+    }
+  }`, event, title, media.URL, media.URL)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return payload
+	}
+
+	bundleDir := filepath.Join(rootDir, "content", "posts", "first-post")
+
+	t.Run("Create", func(t *testing.T) {
+		outputPath, err := site.CreateEntry(payload("entry.create", "First post"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		index := readFile(t, filepath.Join(bundleDir, "index.md"))
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Output path":        {outputPath, bundleDir},
+			"Registry entry":     {registry["post-1"], bundleDir},
+			"cover.jpg":          {readFile(t, filepath.Join(bundleDir, "cover.jpg")), "content of /uploads/cover.jpg"},
+			"photo.jpg":          {readFile(t, filepath.Join(bundleDir, "photo.jpg")), "content of /uploads/photo.jpg"},
+			"inline.png":         {readFile(t, filepath.Join(bundleDir, "inline.png")), "content of /uploads/inline.png"},
+			"Cover downloaded":   {downloads["/uploads/cover.jpg"], 1},
+			"Thumbnail skipped":  {downloads["/uploads/thumbnail_cover.jpg"], 0},
+			"Cover rewritten":    {strings.Contains(index, "url: cover.jpg"), true},
+			"Gallery rewritten":  {strings.Contains(index, "url: photo.jpg"), true},
+			"Content rewritten":  {strings.Contains(index, "Text ![inline](inline.png) and ![external](cover.jpg)"), true},
+			"Thumbnail absolute": {strings.Contains(index, "url: "+media.URL+"/uploads/thumbnail_cover.jpg"), true},
+			"No temporary files": {len(readDir(t, filepath.Dir(bundleDir))), 1},
+		})
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		outputPath, err := site.UpdateEntry(payload("entry.update", "Renamed post"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, statErr := os.Stat(bundleDir)
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Output path":        {outputPath, filepath.Join(rootDir, "content", "posts", "renamed-post")},
+			"Old bundle removed": {os.IsNotExist(statErr), true},
+			"cover.jpg":          {readFile(t, filepath.Join(outputPath, "cover.jpg")), "content of /uploads/cover.jpg"},
+		})
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		outputPath, err := site.DeleteEntry(payload("entry.delete", "Renamed post"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, statErr := os.Stat(outputPath)
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Bundle removed": {os.IsNotExist(statErr), true},
+			"Registry entry": {registry["post-1"], ""},
+		})
+	})
+
+	t.Run("MissingMedia", func(t *testing.T) {
+		entry := payload("entry.create", "Missing")
+		entry.Entry()["Cover"].(map[string]interface{})["url"] = "/missing.jpg"
+
+		_, err := site.CreateEntry(entry)
+		_, statErr := os.Stat(filepath.Join(rootDir, "content", "posts", "missing"))
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Failed":             {err != nil, true},
+			"Bundle not created": {os.IsNotExist(statErr), true},
+		})
+	})
+}
+
+// newTestSite returns the site service keeping the registry entries in the registry map.
+func newTestSite(t *testing.T, site midas.Site, registry midas.Registry) SiteService {
+	midas.RegistryServices = map[string]func(site midas.Site) midas.RegistryService{
+		"mock": func(_ midas.Site) midas.RegistryService {
+			return newMockRegistry(registry)
+		},
+	}
+	site.Registry = midas.RegistrySettings{Type: "mock"}
+
+	service, err := NewSiteService(site)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return service.(SiteService)
+}
+
+// newMockRegistry returns the registry service keeping the entries in the registry map.
+func newMockRegistry(registry midas.Registry) *mock.RegistryService {
+	registryService := mock.NewRegistryService(midas.Site{})
+	registryService.OpenStorageFn = func() error {
+		return nil
+	}
+	registryService.CreateEntryFn = func(id, filename string) error {
+		registry[id] = filename
+		return nil
+	}
+	registryService.ReadEntryFn = func(id string) (string, error) {
+		if filename, ok := registry[id]; ok {
+			return filename, nil
+		}
+
+		return "", midas.Errorf(midas.ErrRegistry, "entry %s not found", id)
+	}
+	registryService.UpdateEntryFn = registryService.CreateEntryFn
+	registryService.DeleteEntryFn = func(id string) error {
+		delete(registry, id)
+		return nil
+	}
+	registryService.FlushFn = func() error {
+		return nil
+	}
+	registryService.SnapshotFn = func() (midas.Registry, error) {
+		snapshot := make(midas.Registry, len(registry))
+		for id, filename := range registry {
+			snapshot[id] = filename
+		}
+
+		return snapshot, nil
+	}
+
+	dependencies := make(midas.Dependencies)
+	registryService.SetDependencyFn = func(id string, dependency midas.Dependency) error {
+		if len(dependency.References) == 0 {
+			delete(dependencies, id)
+		} else {
+			dependencies[id] = dependency
+		}
+
+		return nil
+	}
+	registryService.ReadDependencyFn = func(id string) (midas.Dependency, error) {
+		if dependency, ok := dependencies[id]; ok {
+			return dependency, nil
+		}
+
+		return midas.Dependency{}, midas.Errorf(midas.ErrRegistry, "dependency of %s not found", id)
+	}
+
+	return registryService
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+
+	return string(content)
+}
+
+func readDir(t *testing.T, path string) []os.DirEntry {
+	t.Helper()
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return entries
+}
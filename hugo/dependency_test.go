@@ -0,0 +1,71 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package hugo
+
+import (
+	"github.com/kovansky/midas"
+	"github.com/kovansky/midas/strapi"
+	"github.com/kovansky/midas/testing_utils"
+	"strings"
+	"testing"
+)
+
+func TestSiteService_Dependencies(t *testing.T) {
+	payload, err := strapi.ParsePayload([]byte(`{
+    "event": "entry.create",
+    "createdAt": "2022-01-01T10:10:10.000Z",
+    "model": "post",
+    "entry": {
+      "id": 1,
+      "Title": "Hello",
+      "Content": "Content",
+      "author": {"id": 3, "name": "Jane"},
+      "categories": [{"id": 5, "name": "News"}, {"id": 2, "name": "Go"}],
+      "cover": {"id": 7, "url": "/uploads/cover.png"}
+    }
+  }`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	site := newTestSite(t, midas.Site{
+		RootDir: t.TempDir(),
+		CollectionTypes: map[string]midas.ModelSettings{
+			"post": {
+				OutputDir: "content/posts",
+				Output:    midas.OutputMarkdown,
+				Relations: map[string]string{"author": "writer", "categories": "category"},
+			},
+		},
+	}, midas.Registry{})
+
+	if _, err = site.CreateEntry(payload); err != nil {
+		t.Fatal(err)
+	}
+
+	registry, _ := site.GetRegistryService()
+
+	dependency, err := registry.ReadDependency("post-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testing_utils.AssertTable(t, map[string][]interface{}{
+		"Model":      {dependency.Model, "post"},
+		"Id":         {dependency.Id, "1"},
+		"References": {strings.Join(dependency.References, ","), "category-2,category-5,writer-3"},
+	})
+
+	t.Run("Rollback", func(t *testing.T) {
+		if err = site.Rollback(); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = registry.ReadDependency("post-1")
+		testing_utils.AssertEquals(t, err != nil, true, "Dependency removed")
+	})
+}
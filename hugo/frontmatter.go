@@ -0,0 +1,93 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package hugo
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	yamlDraft = regexp.MustCompile(`^draft\s*:`)
+	tomlDraft = regexp.MustCompile(`^draft\s*=`)
+)
+
+// setDraft sets the draft flag in the front matter of the content, which can be in YAML, TOML or JSON format. If the
+// content has no front matter, a YAML one is added.
+func setDraft(content []byte, draft bool) []byte {
+	value := strconv.FormatBool(draft)
+
+	switch {
+	case hasDelimiter(content, "---"):
+		return setDelimitedDraft(content, "---", yamlDraft, "draft: "+value)
+	case hasDelimiter(content, "+++"):
+		return setDelimitedDraft(content, "+++", tomlDraft, "draft = "+value)
+	case bytes.HasPrefix(bytes.TrimSpace(content), []byte("{")):
+		if withDraft, ok := setJSONDraft(content, draft); ok {
+			return withDraft
+		}
+	}
+
+	return append([]byte("---\ndraft: "+value+"\n---\n"), content...)
+}
+
+// hasDelimiter tells if the content starts with a front matter delimiter line.
+func hasDelimiter(content []byte, delimiter string) bool {
+	firstLine, _, _ := strings.Cut(string(content), "\n")
+
+	return strings.TrimRight(firstLine, "\r ") == delimiter
+}
+
+// setDelimitedDraft replaces the draft line in the front matter between delimiter lines, or adds it before the closing
+// delimiter.
+func setDelimitedDraft(content []byte, delimiter string, draftLine *regexp.Regexp, replacement string) []byte {
+	lines := strings.SplitAfter(string(content), "\n")
+
+	for i := 1; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], "\r\n")
+
+		if draftLine.MatchString(line) {
+			lines[i] = replacement + lines[i][len(line):]
+			return []byte(strings.Join(lines, ""))
+		}
+
+		if strings.TrimRight(line, " ") == delimiter {
+			lines = append(lines[:i], append([]string{replacement + "\n"}, lines[i:]...)...)
+			return []byte(strings.Join(lines, ""))
+		}
+	}
+
+	// Front matter is not closed, so it's not a front matter at all.
+	return append([]byte(delimiter+"\n"+replacement+"\n"+delimiter+"\n"), content...)
+}
+
+// setJSONDraft sets the draft key in the JSON front matter, which is an object at the beginning of the content.
+func setJSONDraft(content []byte, draft bool) ([]byte, bool) {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+
+	var frontMatter map[string]interface{}
+	if err := decoder.Decode(&frontMatter); err != nil {
+		return nil, false
+	}
+
+	frontMatter["draft"] = draft
+
+	var encoded bytes.Buffer
+	encoder := json.NewEncoder(&encoded)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(frontMatter); err != nil {
+		return nil, false
+	}
+
+	return append(bytes.TrimRight(encoded.Bytes(), "\n"), content[decoder.InputOffset():]...), true
+}
@@ -0,0 +1,29 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package hugo
+
+import (
+	"github.com/kovansky/midas/testing_utils"
+	"testing"
+)
+
+func TestSetDraft(t *testing.T) {
+	tests := map[string][]string{
+		"YAML replace":    {"---\ntitle: Test\ndraft: false\n---\nContent", "---\ntitle: Test\ndraft: true\n---\nContent"},
+		"YAML insert":     {"---\ntitle: Test\n---\nContent", "---\ntitle: Test\ndraft: true\n---\nContent"},
+		"TOML replace":    {"+++\ntitle = \"Test\"\ndraft = false\n+++\nContent", "+++\ntitle = \"Test\"\ndraft = true\n+++\nContent"},
+		"TOML insert":     {"+++\ntitle = \"Test\"\n+++\nContent", "+++\ntitle = \"Test\"\ndraft = true\n+++\nContent"},
+		"JSON":            {"{\"title\": \"<Test>\"}\nContent", "{\n  \"draft\": true,\n  \"title\": \"<Test>\"\n}\nContent"},
+		"No front matter": {"Content", "---\ndraft: true\n---\nContent"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			testing_utils.AssertEquals(t, string(setDraft([]byte(test[0]), true)), test[1], "Content")
+		})
+	}
+}
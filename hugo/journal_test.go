@@ -0,0 +1,150 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package hugo
+
+import (
+	"fmt"
+	"github.com/kovansky/midas"
+	"github.com/kovansky/midas/bluemonday"
+	"github.com/kovansky/midas/strapi"
+	"github.com/kovansky/midas/testing_utils"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSiteService_Rollback(t *testing.T) {
+	midas.Sanitizer = bluemonday.NewSanitizerService()
+
+	rootDir := t.TempDir()
+	registry := midas.Registry{}
+
+	site := newTestSite(t, midas.Site{
+		RootDir: rootDir,
+		CollectionTypes: map[string]midas.ModelSettings{
+			"post": {OutputDir: "posts", Output: midas.OutputMarkdown},
+		},
+		SingleTypes: map[string]midas.ModelSettings{
+			"homepage": {OutputDir: "data"},
+		},
+	}, registry)
+
+	payload := func(model string, id int, title string) midas.Payload {
+		payload, err := strapi.ParsePayload([]byte(fmt.Sprintf(`{
+    "event": "entry.update",
+    "createdAt": "2022-01-01T10:10:10.000Z",
+    "model": "%s",
+    "entry": {"id": %d, "Title": "%s", "Content": "%s content"}
+  }`, model, id, title, title)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return payload
+	}
+
+	firstPath := filepath.Join(rootDir, "posts", "first.md")
+
+	if _, err := site.CreateEntry(payload("post", 1, "First")); err != nil {
+		t.Fatal(err)
+	}
+	if err := site.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	committed := readFile(t, firstPath)
+
+	t.Run("Update", func(t *testing.T) {
+		outputPath, err := site.UpdateEntry(payload("post", 1, "Renamed"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err = site.Rollback(); err != nil {
+			t.Fatal(err)
+		}
+
+		_, statErr := os.Stat(outputPath)
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Old file restored": {readFile(t, firstPath), committed},
+			"New file removed":  {os.IsNotExist(statErr), true},
+			"Registry entry":    {registry["post-1"], firstPath},
+		})
+	})
+
+	t.Run("Create", func(t *testing.T) {
+		outputPath, err := site.CreateEntry(payload("post", 2, "Second"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err = site.Rollback(); err != nil {
+			t.Fatal(err)
+		}
+
+		_, statErr := os.Stat(outputPath)
+		_, inRegistry := registry["post-2"]
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"File removed":       {os.IsNotExist(statErr), true},
+			"Registry entry":     {inRegistry, false},
+			"Other entry intact": {registry["post-1"], firstPath},
+		})
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		if _, err := site.DeleteEntry(payload("post", 1, "First")); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := site.Rollback(); err != nil {
+			t.Fatal(err)
+		}
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"File restored":  {readFile(t, firstPath), committed},
+			"Registry entry": {registry["post-1"], firstPath},
+		})
+	})
+
+	t.Run("Single", func(t *testing.T) {
+		if err := os.Mkdir(filepath.Join(rootDir, "data"), 0775); err != nil {
+			t.Fatal(err)
+		}
+		singlePath := filepath.Join(rootDir, "data", "homepage.json")
+		if err := os.WriteFile(singlePath, []byte(`{"old":true}`), 0664); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := site.UpdateSingle(payload("homepage", 1, "Home")); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := site.Rollback(); err != nil {
+			t.Fatal(err)
+		}
+
+		testing_utils.AssertEquals(t, readFile(t, singlePath), `{"old":true}`, "Single file")
+	})
+
+	t.Run("Commit", func(t *testing.T) {
+		outputPath, err := site.UpdateEntry(payload("post", 1, "Renamed"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err = site.Commit(); err != nil {
+			t.Fatal(err)
+		}
+
+		if err = site.Rollback(); err != nil {
+			t.Fatal(err)
+		}
+
+		testing_utils.AssertEquals(t, registry["post-1"], outputPath, "Change kept")
+	})
+}
@@ -0,0 +1,73 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package hugo
+
+import (
+	"github.com/kovansky/midas"
+	"path/filepath"
+	"strings"
+)
+
+// language returns the site language of the CMS locale, or empty string for the entries without locale and on
+// monolingual sites.
+func (l Layout) language(locale string) string {
+	if locale == "" || !l.Site.Locales.Enabled() {
+		return ""
+	}
+
+	return l.Site.Locales.Language(locale)
+}
+
+// checkLocales checks if the entries of the model can be placed according to the locale settings.
+func (l Layout) checkLocales(modelName string, model *midas.ModelSettings, lang string) error {
+	switch l.Site.Locales.Strategy {
+	case "", midas.LocaleDirectory:
+		return nil
+	case midas.LocaleFilename:
+		// Translations would share the bundle directory, which is replaced as a whole.
+		if model.Bundle && lang != "" {
+			return midas.Errorf(midas.ErrSiteConfig, "bundles of model %s require directory locale strategy", modelName)
+		}
+
+		return nil
+	default:
+		return midas.Errorf(midas.ErrSiteConfig, "locale strategy %s is not supported", l.Site.Locales.Strategy)
+	}
+}
+
+// modelOutputDir returns the absolute output directory of the model entries in the language. With directory locale
+// strategy, the language directory is placed after the first directory of the output dir (the content or data dir),
+// i.e. content/posts becomes content/<lang>/posts.
+func (l Layout) modelOutputDir(model *midas.ModelSettings, lang string) string {
+	outputDir := model.OutputDir
+	if !filepath.IsAbs(outputDir) {
+		outputDir = filepath.Join(l.Site.RootDir, outputDir)
+	}
+
+	if lang == "" || l.Site.Locales.Strategy == midas.LocaleFilename {
+		return outputDir
+	}
+
+	rel, err := filepath.Rel(l.Site.RootDir, outputDir)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return filepath.Join(outputDir, lang)
+	}
+
+	parts := strings.SplitN(rel, string(filepath.Separator), 2)
+
+	return filepath.Join(append([]string{l.Site.RootDir, parts[0], lang}, parts[1:]...)...)
+}
+
+// languageSuffix returns the language suffix of the filename (without the dot) with filename locale strategy. Entries
+// in the default language have no suffix.
+func (l Layout) languageSuffix(lang string) string {
+	if lang == "" || l.Site.Locales.Strategy != midas.LocaleFilename || lang == l.Site.Locales.Default {
+		return ""
+	}
+
+	return lang
+}
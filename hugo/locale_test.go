@@ -0,0 +1,162 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package hugo
+
+import (
+	"fmt"
+	"github.com/kovansky/midas"
+	"github.com/kovansky/midas/strapi"
+	"github.com/kovansky/midas/testing_utils"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSiteService_Locales(t *testing.T) {
+	payload := func(id int, locale, title string) midas.Payload {
+		payload, err := strapi.ParsePayload([]byte(fmt.Sprintf(`{
+    "event": "entry.create",
+    "createdAt": "2022-01-01T10:10:10.000Z",
+    "model": "post",
+    "entry": {
+      "id": %d,
+      "Title": "%s",
+      "Content": "Content",
+      "locale": "%s",
+      "localizations": [{"id": 3, "locale": "en-US"}, {"id": 5, "locale": "de"}]
+    }
+  }`, id, title, locale)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return payload
+	}
+
+	newSite := func(locales midas.LocaleSettings, model midas.ModelSettings) (SiteService, midas.Registry) {
+		registry := midas.Registry{}
+
+		return newTestSite(t, midas.Site{
+			RootDir:         t.TempDir(),
+			Locales:         locales,
+			CollectionTypes: map[string]midas.ModelSettings{"post": model},
+		}, registry), registry
+	}
+
+	mapping := map[string]string{"en-US": "en"}
+	markdown := midas.ModelSettings{OutputDir: "content/posts", Output: midas.OutputMarkdown}
+
+	t.Run("Directory", func(t *testing.T) {
+		site, registry := newSite(midas.LocaleSettings{Mapping: mapping}, markdown)
+
+		plPath, err := site.CreateEntry(payload(4, "pl", "Witaj"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		enPath, err := site.CreateEntry(payload(3, "en-US", "Hello"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Polish path":          {plPath, filepath.Join(site.Site.RootDir, "content", "pl", "posts", "witaj.md")},
+			"English path":         {enPath, filepath.Join(site.Site.RootDir, "content", "en", "posts", "hello.md")},
+			"Registry id":          {registry["post-4-pl"], plPath},
+			"Translation key":      {strings.Contains(readFile(t, plPath), "translationKey: post-3\n"), true},
+			"Same translation key": {strings.Contains(readFile(t, enPath), "translationKey: post-3\n"), true},
+		})
+	})
+
+	t.Run("Monolingual", func(t *testing.T) {
+		// The CMS has localization enabled, but the site has no locale settings.
+		site, registry := newSite(midas.LocaleSettings{}, markdown)
+
+		path, err := site.CreateEntry(payload(3, "en-US", "Hello"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Path":        {path, filepath.Join(site.Site.RootDir, "content", "posts", "hello.md")},
+			"Registry id": {registry["post-3"], path},
+		})
+	})
+
+	t.Run("UnlocalizedRegistryId", func(t *testing.T) {
+		site, registry := newSite(midas.LocaleSettings{Mapping: mapping}, markdown)
+
+		// The translation registered before the locale was added to the registry ids.
+		oldPath := filepath.Join(site.Site.RootDir, "content", "pl", "posts", "witaj.md")
+		if err := os.MkdirAll(filepath.Dir(oldPath), 0775); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(oldPath, []byte("old"), 0664); err != nil {
+			t.Fatal(err)
+		}
+		registry["post-4"] = oldPath
+
+		updatedPath, err := site.UpdateEntry(payload(4, "pl", "Witaj"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Replaced":         {updatedPath, oldPath},
+			"Localized id":     {registry["post-4-pl"], oldPath},
+			"Unlocalized id":   {registry["post-4"], ""},
+			"Content replaced": {readFile(t, oldPath) != "old", true},
+		})
+
+		registry["post-4"] = oldPath
+		delete(registry, "post-4-pl")
+
+		deletedPath, err := site.DeleteEntry(payload(4, "pl", "Witaj"))
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Error":        {err, nil},
+			"Deleted":      {deletedPath, oldPath},
+			"Unregistered": {len(registry), 0},
+		})
+	})
+
+	t.Run("Filename", func(t *testing.T) {
+		site, _ := newSite(midas.LocaleSettings{Mapping: mapping, Strategy: midas.LocaleFilename, Default: "en"}, markdown)
+
+		plPath, err := site.CreateEntry(payload(4, "pl", "Post"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		enPath, err := site.CreateEntry(payload(3, "en-US", "Post"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Polish path":  {plPath, filepath.Join(site.Site.RootDir, "content", "posts", "post.pl.md")},
+			"Default path": {enPath, filepath.Join(site.Site.RootDir, "content", "posts", "post.md")},
+		})
+	})
+
+	t.Run("FilenameBundle", func(t *testing.T) {
+		bundle := markdown
+		bundle.Bundle = true
+		site, _ := newSite(midas.LocaleSettings{Strategy: midas.LocaleFilename}, bundle)
+
+		_, err := site.CreateEntry(payload(4, "pl", "Post"))
+
+		testing_utils.AssertEquals(t, midas.ErrorCode(err), midas.ErrSiteConfig, "Error code")
+	})
+
+	t.Run("UnknownStrategy", func(t *testing.T) {
+		site, _ := newSite(midas.LocaleSettings{Strategy: "subdomain"}, markdown)
+
+		_, err := site.CreateEntry(payload(4, "pl", "Post"))
+
+		testing_utils.AssertEquals(t, midas.ErrorCode(err), midas.ErrSiteConfig, "Error code")
+	})
+}
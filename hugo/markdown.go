@@ -0,0 +1,28 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package hugo
+
+import (
+	"github.com/kovansky/midas"
+	"github.com/kovansky/midas/content"
+)
+
+// renderMarkdown renders the entry as markdown content: entry fields go to the front matter and the body field becomes
+// the content. Hugo's predefined front matter variables (title, date, lastmod, draft, translationKey) are filled from
+// the entry and metadata, unless the entry has fields with the same names.
+func renderMarkdown(model *midas.ModelSettings, payload midas.Payload) ([]byte, error) {
+	entry := payload.Entry()
+
+	return content.RenderMarkdown(model, payload, map[string]interface{}{
+		"title":   content.Title(model, entry),
+		"date":    content.Date(entry),
+		"lastmod": entry["updatedAt"],
+		"draft":   payload.Metadata()["draft"],
+		// Links the translations of the entry.
+		"translationKey": payload.Metadata()["translationKey"],
+	})
+}
@@ -0,0 +1,117 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package hugo
+
+import (
+	"github.com/kovansky/midas"
+	"github.com/kovansky/midas/strapi"
+	"github.com/kovansky/midas/testing_utils"
+	"testing"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	payload, err := strapi.ParsePayload([]byte(`{
+    "event": "entry.update",
+    "createdAt": "2022-01-01T10:10:10.000Z",
+    "model": "post",
+    "entry": {
+      "id": 1,
+      "Title": "Test \"post\"",
+      "Content": "# Hello",
+      "Tags": ["go", "hugo"],
+      "Rating": 4.5,
+      "Author": {"id": 2, "Name": "John", "Avatar": null},
+      "createdAt": "2022-01-01T10:10:10.000Z",
+      "updatedAt": "2022-01-02T10:10:10.000Z",
+      "publishedAt": null
+    }
+  }`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]string{
+		midas.FrontMatterYAML: `---
+Author:
+    Name: John
+    id: 2
+Rating: 4.5
+Tags:
+    - go
+    - hugo
+Title: Test "post"
+createdAt: 2022-01-01T10:10:10Z
+date: 2022-01-01T10:10:10Z
+draft: true
+id: 1
+lastmod: 2022-01-02T10:10:10Z
+title: Test "post"
+updatedAt: 2022-01-02T10:10:10Z
+---
+
+# Hello
+`,
+		midas.FrontMatterTOML: `+++
+Rating = 4.5
+Tags = ["go", "hugo"]
+Title = "Test \"post\""
+createdAt = 2022-01-01T10:10:10Z
+date = 2022-01-01T10:10:10Z
+draft = true
+id = 1
+lastmod = 2022-01-02T10:10:10Z
+title = "Test \"post\""
+updatedAt = 2022-01-02T10:10:10Z
+
+[Author]
+Name = "John"
+id = 2
++++
+
+# Hello
+`,
+		midas.FrontMatterJSON: `{
+  "Author": {
+    "Name": "John",
+    "id": 2
+  },
+  "Rating": 4.5,
+  "Tags": [
+    "go",
+    "hugo"
+  ],
+  "Title": "Test \"post\"",
+  "createdAt": "2022-01-01T10:10:10Z",
+  "date": "2022-01-01T10:10:10Z",
+  "draft": true,
+  "id": 1,
+  "lastmod": "2022-01-02T10:10:10Z",
+  "title": "Test \"post\"",
+  "updatedAt": "2022-01-02T10:10:10Z"
+}
+
+# Hello
+`,
+	}
+
+	for format, expected := range tests {
+		t.Run(format, func(t *testing.T) {
+			content, err := renderMarkdown(&midas.ModelSettings{Output: midas.OutputMarkdown, FrontMatter: format}, payload)
+
+			testing_utils.AssertTable(t, map[string][]interface{}{
+				"Error":   {err, nil},
+				"Content": {string(content), expected},
+			})
+		})
+	}
+
+	t.Run("UnsupportedFormat", func(t *testing.T) {
+		_, err := renderMarkdown(&midas.ModelSettings{Output: midas.OutputMarkdown, FrontMatter: "xml"}, payload)
+
+		testing_utils.AssertEquals(t, midas.ErrorCode(err), midas.ErrSiteConfig, "Error code")
+	})
+}
@@ -7,11 +7,12 @@
 package hugo
 
 import (
+	"bytes"
 	"context"
 	"errors"
-	"fmt"
 	"github.com/kovansky/midas"
 	"github.com/kovansky/midas/concurrent"
+	"github.com/kovansky/midas/content"
 	"github.com/rs/zerolog"
 	"html/template"
 	"os"
@@ -22,72 +23,33 @@ import (
 var _ midas.SiteService = (*SiteService)(nil)
 
 type SiteService struct {
-	Site midas.Site
-
-	registry midas.RegistryService
+	content.Service
 }
 
 func NewSiteService(config midas.Site) (midas.SiteService, error) {
-	if _, ok := midas.RegistryServices[config.Registry.Type]; !ok {
-		return nil, midas.Errorf(midas.ErrSiteConfig, "requested registry type %s does not exit", config.Registry.Type)
-	}
-
-	siteService := SiteService{
-		Site:     config,
-		registry: midas.RegistryServices[config.Registry.Type](config),
-	}
-
-	err := siteService.registry.OpenStorage()
+	service, err := content.NewService(config, Layout{Site: config})
 	if err != nil {
-		err = siteService.registry.CreateStorage()
-		if err != nil {
-			return nil, err
-		}
+		return nil, err
 	}
 
-	return siteService, nil
-}
-
-func (s SiteService) GetRegistryService() (midas.RegistryService, error) {
-	return s.registry, nil
+	return SiteService{service}, nil
 }
 
 func (s SiteService) BuildSite(useCache bool, _ zerolog.Logger) error {
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
-	var arg = s.constructBuildArgs(useCache, false)
-
-	cmd := exec.CommandContext(ctx, "hugo", arg...)
-	cmd.Dir = s.Site.RootDir
+	err := concurrent.Run(s.Site, "hugo", func(ctx context.Context) *exec.Cmd {
+		cmd := exec.CommandContext(ctx, "hugo", s.constructBuildArgs(useCache, false)...)
+		cmd.Dir = s.Site.RootDir
 
-	err := midas.Concurrents.Add(concurrent.New(s.Site, cancel))
+		return cmd
+	})
 	if err != nil {
-		if midas.ErrorCode(err) != midas.ErrProcessNotFound {
-			return err
-		}
+		return err
 	}
 
-	out, err := cmd.CombinedOutput()
-
-	select {
-	case <-ctx.Done():
-		switch ctx.Err() {
-		case context.Canceled:
-			return midas.Errorf(midas.ErrCancelled, "process cancelled")
-		}
-	default:
-		midas.Concurrents.Remove(s.Site.SiteName)
-		if err != nil {
-			return midas.Errorf(midas.ErrInternal, "hugo build errored: %s\ncommand output: %s", err, out)
-		}
-
-		if s.Site.BuildDrafts {
-			if err = s.BuildDrafts(); err != nil {
-				return err
-			}
-		}
+	if s.Site.BuildDrafts {
+		return s.BuildDrafts()
 	}
+
 	return nil
 }
 
@@ -99,33 +61,18 @@ func (s SiteService) constructBuildArgs(useCache, isDraft bool) (arg []string) {
 		arg = append(arg, "--ignoreCache")
 	}
 
-	if !isDraft {
-		if s.Site.OutputSettings.Build != "" {
-			arg = append(arg, "-d", s.Site.OutputSettings.Build)
-		} else {
-			s.Site.OutputSettings.Build = "public"
-		}
-	} else {
-		arg = append(arg, "-d")
-		if s.Site.OutputSettings.Draft != "" {
-			arg = append(arg, s.Site.OutputSettings.Draft)
-		} else {
-			arg = append(arg, "publicDrafts")
-		}
+	output := s.Site.BuildOutput(isDraft)
+	arg = append(arg, "-d", output.Dir)
 
-		arg = append(arg, "-e")
-		if s.Site.OutputSettings.DraftEnvironment != "" {
-			arg = append(arg, s.Site.OutputSettings.DraftEnvironment)
-		} else {
-			arg = append(arg, "development")
-		}
+	if output.Drafts {
+		arg = append(arg, "-e", output.Environment)
 
 		// -D is for build drafts, -E for build expired, -F for build future
 		arg = append(arg, "-D", "-E", "-F")
 
 		// Add baseUrl, if specified
-		if s.Site.DraftsUrl != "" {
-			arg = append(arg, "-b", s.Site.DraftsUrl)
+		if output.BaseUrl != "" {
+			arg = append(arg, "-b", output.BaseUrl)
 		}
 	}
 
@@ -140,294 +87,150 @@ func (s SiteService) BuildDrafts() error {
 
 	out, err := cmd.Output()
 	if err != nil {
-		return midas.Errorf(midas.ErrInternal, "hugo draft build errored: %s\ncommand output: %s", err, out)
+		return midas.Errorf(midas.ErrDraftsBuild, "hugo draft build errored: %s\ncommand output: %s", err, out)
 	}
 
 	return nil
 }
 
-func (s SiteService) CreateEntry(payload midas.Payload) (string, error) {
-	// Set archetype path and output directory
-	modelName := payload.Metadata()["model"].(string)
-	model, _ := s.getModel(modelName)
-	archetypePath := model.ArchetypePath
-	outputDir := model.OutputDir
-
-	if outputDir == "false" {
-		return "", nil
-	}
-
-	if !filepath.IsAbs(archetypePath) {
-		archetypePath = filepath.Join(s.Site.RootDir, archetypePath)
-	}
-	if !filepath.IsAbs(outputDir) {
-		outputDir = filepath.Join(s.Site.RootDir, outputDir)
-	}
-
-	// Check if archetype exists
-	if !fileExists(archetypePath) {
-		return "", midas.Errorf(midas.ErrSiteConfig, "archetype for model %s does not exist", modelName)
-	}
-	// Check if output dir exists, attempt to create it if it doesn't
-	if !fileExists(outputDir) {
-		err := os.Mkdir(outputDir, 0775)
-		if err != nil {
-			return "", err
-		}
-	}
-
-	// Format output filename
-	var titleField string
-
-	if model.Fields.Title == nil {
-		titleField = "Title"
-	} else {
-		titleField = *model.Fields.Title
-	}
-
-	title := fmt.Sprintf("%v", payload.Entry()[titleField])
-	slug := midas.CreateSlug(title)
-	outputPath := filepath.Join(outputDir, slug+".html")
-
-	// Check if output filename is free
-	if fileExists(outputPath) {
-		return "", midas.Errorf(midas.ErrInvalid, "output file %s already exists", filepath.Base(outputPath))
-	}
-
-	// Read archetype file
-	tmpl, err := template.ParseFiles(archetypePath)
-	if err != nil {
-		return "", err
-	}
-
-	// Create output file
-	output, err := os.Create(outputPath)
-	defer func(output *os.File) {
-		_ = output.Close()
-	}(output)
-
-	if err != nil {
-		return "", err
-	}
-
-	// Parse archetype and write it to output
-	err = s.executeTemplate(tmpl, output, payload)
-	if err != nil {
-		return "", err
-	}
-
-	// Add entry to registry
-	entryId := s.EntryId(payload)
-
-	if err = s.registry.CreateEntry(entryId, outputPath); err != nil {
-		return outputPath, err
-	}
-	if err = s.registry.Flush(); err != nil {
-		return outputPath, err
-	}
+// EntryId generates the entry to be used in registry. Entries with locale have it appended, as translations are
+// separate files.
+func (s SiteService) EntryId(payload midas.Payload) string {
+	return content.RegistryId(s.Site, payload)
+}
 
-	return outputPath, nil
+// fileExists return true if path exists or false otherwise
+func fileExists(filename string) bool {
+	_, err := os.Stat(filename)
+	return !errors.Is(err, os.ErrNotExist)
 }
 
-func (s SiteService) UpdateEntry(payload midas.Payload) (string, error) {
-	// Set archetype path
-	modelName := payload.Metadata()["model"].(string)
-	model, _ := s.getModel(modelName)
-	archetypePath := model.ArchetypePath
-	configOutputDir := model.OutputDir
+// Layout writes the entries of the collection types as HTML (from the archetype) or markdown content files, or as page
+// bundles, and the single types as JSON data files. Translations are placed by the locale settings of the site.
+type Layout struct {
+	Site midas.Site
+}
 
-	if configOutputDir == "false" {
-		return "", nil
-	}
+// EntryPath returns the output dir of the entry in its language and the filename, with the language suffix in the
+// extension. Bundles are directories, so they have no extension.
+func (l Layout) EntryPath(modelName string, model *midas.ModelSettings, payload midas.Payload) (string, string, string, error) {
+	lang := l.language(content.Locale(l.Site, payload))
 
-	if !filepath.IsAbs(archetypePath) {
-		archetypePath = filepath.Join(s.Site.RootDir, archetypePath)
+	if err := l.checkLocales(modelName, model, lang); err != nil {
+		return "", "", "", err
 	}
 
-	// Check if archetype exists
-	if !fileExists(archetypePath) {
-		return "", midas.Errorf(midas.ErrSiteConfig, "archetype for model %s does not exist", modelName)
+	// Check if the output can be rendered (i.e. archetype exists)
+	if _, err := l.renderer(modelName, model); err != nil {
+		return "", "", "", err
 	}
 
-	// Get old path
-	entryId := s.EntryId(payload)
-	oldPath, err := s.registry.ReadEntry(entryId)
-	outputDir := filepath.Dir(oldPath)
+	filename, err := content.EntryFilename(modelName, model, payload)
 	if err != nil {
-		// If entry not in the registry, create empty one, otherwise UpdateEntry later will complain
-		_ = s.registry.CreateEntry(entryId, "")
-		// Read output dir in normal way
-		outputDir = model.OutputDir
-		if !filepath.IsAbs(outputDir) {
-			outputDir = filepath.Join(s.Site.RootDir, outputDir)
-		}
+		return "", "", "", err
 	}
 
-	// Check if output dir exists, attempt to create it if it doesn't
-	if !fileExists(outputDir) {
-		err := os.Mkdir(outputDir, 0775)
-		if err != nil {
-			return "", err
+	var extension string
+	if !model.Bundle {
+		if suffix := l.languageSuffix(lang); suffix != "" {
+			extension = "." + suffix
 		}
+		extension += outputExtension(model)
 	}
 
-	// Format new output filename
-	var titleField string
-
-	if model.Fields.Title == nil {
-		titleField = "Title"
-	} else {
-		titleField = *model.Fields.Title
-	}
-
-	title := fmt.Sprintf("%v", payload.Entry()[titleField])
-	slug := midas.CreateSlug(title)
-	outputPath := filepath.Join(outputDir, slug+".html")
-
-	// Check if output filename is free (excluding situation where name doesn't change)
-	if fileExists(outputPath) && filepath.Base(outputPath) != filepath.Base(oldPath) {
-		return "", midas.Errorf(midas.ErrInvalid, "output file %s already exists", filepath.Base(outputPath))
-	}
-
-	// Remove old entry if exists
-	if oldPath != "" && fileExists(oldPath) {
-		_ = os.Remove(oldPath)
-	}
-
-	// Read archetype file
-	tmpl, err := template.ParseFiles(archetypePath)
-	if err != nil {
-		return "", err
-	}
-
-	// Create output file
-	output, err := os.Create(outputPath)
-	defer func(output *os.File) {
-		_ = output.Close()
-	}(output)
-
-	if err != nil {
-		return "", err
-	}
+	return l.modelOutputDir(model, lang), filename, extension, nil
+}
 
-	// Parse archetype and write it to output
-	err = s.executeTemplate(tmpl, output, payload)
+func (l Layout) RenderEntry(model *midas.ModelSettings, payload midas.Payload) ([]byte, error) {
+	render, err := l.renderer(payload.Metadata()["model"].(string), model)
 	if err != nil {
-		return "", err
-	}
-
-	// Update entry in registry
-	if err = s.registry.UpdateEntry(entryId, outputPath); err != nil {
-		return outputPath, err
-	}
-	if err = s.registry.Flush(); err != nil {
-		return outputPath, err
+		return nil, err
 	}
 
-	return outputPath, nil
+	return render(payload)
 }
 
-func (s SiteService) DeleteEntry(payload midas.Payload) (string, error) {
-	// Get entry path
-	entryId := s.EntryId(payload)
-	entryPath, err := s.registry.ReadEntry(entryId)
-	if err != nil {
-		return "", err
-	}
+// SinglePath returns the path of the data file of the single type, in the language of the locale.
+func (l Layout) SinglePath(modelName string, model *midas.ModelSettings, locale string) string {
+	lang := l.language(locale)
 
-	// Remove entry
-	if err = os.Remove(entryPath); err != nil {
-		return "", nil
+	filename := modelName
+	if suffix := l.languageSuffix(lang); suffix != "" {
+		filename += "." + suffix
 	}
 
-	// Remove entry from registry
-	if err = s.registry.DeleteEntry(entryId); err != nil {
-		return entryPath, err
-	}
-	if err = s.registry.Flush(); err != nil {
-		return entryPath, err
-	}
+	return filepath.Join(l.modelOutputDir(model, lang), filename+".json")
+}
 
-	return entryPath, nil
+func (l Layout) RenderSingle(_ *midas.ModelSettings, payload midas.Payload) ([]byte, error) {
+	return content.RenderJSON(payload)
 }
 
-func (s SiteService) UpdateSingle(payload midas.Payload) (string, error) {
-	// Set output directory
-	modelName := payload.Metadata()["model"].(string)
-	model, _ := s.getModel(modelName)
-	outputDir := model.OutputDir
-	if !filepath.IsAbs(outputDir) {
-		outputDir = filepath.Join(s.Site.RootDir, outputDir)
-	}
+// renderer returns the function rendering the entry content in the output format of the model. Returns an error if the
+// output can't be rendered, i.e. the archetype doesn't exist.
+func (l Layout) renderer(modelName string, model *midas.ModelSettings) (func(payload midas.Payload) ([]byte, error), error) {
+	switch model.Output {
+	case "", midas.OutputHTML:
+		archetypePath := model.ArchetypePath
+		if !filepath.IsAbs(archetypePath) {
+			archetypePath = filepath.Join(l.Site.RootDir, archetypePath)
+		}
 
-	// Check if output dir exists, attempt to create it if it doesn't
-	if !fileExists(outputDir) {
-		err := os.Mkdir(outputDir, 0775)
-		if err != nil {
-			return "", err
+		// Check if archetype exists
+		if !fileExists(archetypePath) {
+			return nil, midas.Errorf(midas.ErrSiteConfig, "archetype for model %s does not exist", modelName)
 		}
-	}
 
-	// Format output filename
-	outputPath := filepath.Join(outputDir, fmt.Sprintf("%s.json", modelName))
+		return func(payload midas.Payload) ([]byte, error) {
+			// Read archetype file
+			tmpl, err := template.ParseFiles(archetypePath)
+			if err != nil {
+				return nil, err
+			}
 
-	// Sanitize the entry
-	entry := payload.Entry()
-	entry = sanitizeHtmlInMap(entry)
+			return executeTemplate(tmpl, model, payload)
+		}, nil
+	case midas.OutputMarkdown:
+		return func(payload midas.Payload) ([]byte, error) {
+			return renderMarkdown(model, payload)
+		}, nil
+	default:
+		return nil, midas.Errorf(midas.ErrSiteConfig, "output %s of model %s is not supported", model.Output, modelName)
+	}
+}
 
-	payload.SetEntry(entry)
+// WriteEntry renders the entry and writes it to the output path, as a file or a bundle.
+func (l Layout) WriteEntry(model *midas.ModelSettings, payload midas.Payload, outputPath string) error {
+	modelName := payload.Metadata()["model"].(string)
 
-	asJson, err := payload.MarshalJSON()
+	render, err := l.renderer(modelName, model)
 	if err != nil {
-		return "", err
+		return err
 	}
 
-	// Open output file
-	output, err := os.OpenFile(outputPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0775)
-	defer func(outout *os.File) {
-		_ = output.Close()
-	}(output)
-
-	if err != nil {
-		return "", err
+	if model.Bundle {
+		return l.writeBundle(model, render, payload, outputPath)
 	}
 
-	// Write the output
-	_, err = output.Write(asJson)
+	content, err := render(payload)
 	if err != nil {
-		return "", err
+		return err
 	}
 
-	return outputPath, nil
-}
-
-// EntryId generates the entry to be used in registry.
-func (s SiteService) EntryId(payload midas.Payload) string {
-	return fmt.Sprintf("%v-%v", payload.Metadata()["model"], payload.Entry()["id"])
+	return os.WriteFile(outputPath, content, 0664)
 }
 
-// getModel returns a model from any type (collection or single), and true if model is single or false otherwise.
-func (s SiteService) getModel(model string) (*midas.ModelSettings, bool) {
-	if m, ok := s.Site.CollectionTypes[model]; ok {
-		return &m, false
-	} else if m, ok := s.Site.SingleTypes[model]; ok {
-		return &m, true
+// outputExtension returns the extension of entry files in the output format of the model.
+func outputExtension(model *midas.ModelSettings) string {
+	if model.Output == midas.OutputMarkdown {
+		return ".md"
 	}
 
-	return nil, true
-}
-
-// fileExists return true if path exists or false otherwise
-func fileExists(filename string) bool {
-	_, err := os.Stat(filename)
-	return !errors.Is(err, os.ErrNotExist)
+	return ".html"
 }
 
-// executeTemplate sanitizes the HTML and executes the template to the output file
-func (s SiteService) executeTemplate(tmpl *template.Template, output *os.File, payload midas.Payload) (err error) {
-	modelName := payload.Metadata()["model"].(string)
-	model, _ := s.getModel(modelName)
-
+// executeTemplate sanitizes the HTML and executes the template, returning the content of the output file
+func executeTemplate(tmpl *template.Template, model *midas.ModelSettings, payload midas.Payload) ([]byte, error) {
 	sanitized := payload.Entry()
 	if model.Fields.HTML != nil && len(*model.Fields.HTML) > 0 {
 		for _, field := range *model.Fields.HTML {
@@ -435,65 +238,21 @@ func (s SiteService) executeTemplate(tmpl *template.Template, output *os.File, p
 		}
 	}
 
-	// Parse archetype and write it to output
-	err = tmpl.Execute(output, struct {
+	// Parse archetype
+	var content bytes.Buffer
+	err := tmpl.Execute(&content, struct {
 		Metadata map[string]interface{}
 		Entry    map[string]interface{}
 	}{payload.Metadata(), sanitized})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
-}
-
-// sanitizeHtmlInMap iterates (recursively) through given map and passes each value through HTML sanitizer.
-func sanitizeHtmlInMap(entry map[string]interface{}) map[string]interface{} {
-	output := make(map[string]interface{})
-
-	for key, value := range entry {
-		// Check value type
-		switch value.(type) {
-		case map[string]interface{}:
-			output[key] = sanitizeHtmlInMap(value.(map[string]interface{}))
-			break
-		case []interface{}:
-			output[key] = sanitizeHtmlInSlice(value.([]interface{}))
-			break
-		default:
-			if stringed, ok := value.(string); ok {
-				output[key] = midas.Sanitizer.Sanitize(stringed)
-			} else {
-				output[key] = value
-			}
-
-		}
-	}
-
-	return output
-}
-
-// sanitizeHtmlInSlice iterates (recursively) through given slice and passes each value through HTML sanitizer.
-func sanitizeHtmlInSlice(entry []interface{}) []interface{} {
-	output := make([]interface{}, len(entry))
-
-	for key, value := range entry {
-		// Check value type
-		switch value.(type) {
-		case map[string]interface{}:
-			output[key] = sanitizeHtmlInMap(value.(map[string]interface{}))
-			break
-		case []interface{}:
-			output[key] = sanitizeHtmlInSlice(value.([]interface{}))
-			break
-		default:
-			if stringed, ok := value.(string); ok {
-				output[key] = midas.Sanitizer.Sanitize(stringed)
-			} else {
-				output[key] = value
-			}
-		}
+	// Unpublished entries are kept as drafts, so they are present only in the drafts build.
+	result := content.Bytes()
+	if draft, ok := payload.Metadata()["draft"].(bool); ok {
+		result = setDraft(result, draft)
 	}
 
-	return output
+	return result, nil
 }
@@ -0,0 +1,156 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package hugo
+
+import (
+	"fmt"
+	"github.com/kovansky/midas"
+	"github.com/kovansky/midas/bluemonday"
+	"github.com/kovansky/midas/strapi"
+	"github.com/kovansky/midas/testing_utils"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSiteService_Sync(t *testing.T) {
+	midas.Sanitizer = bluemonday.NewSanitizerService()
+
+	root := t.TempDir()
+	postsDir := filepath.Join(root, "content", "posts")
+	dataDir := filepath.Join(root, "data")
+
+	for _, dir := range []string{postsDir, dataDir} {
+		if err := os.MkdirAll(dir, 0775); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files := map[string]string{
+		"kept":      filepath.Join(postsDir, "hello.md"),
+		"stale":     filepath.Join(postsDir, "removed.md"),
+		"untracked": filepath.Join(postsDir, "handwritten.md"),
+		"single":    filepath.Join(dataDir, "footer.json"),
+	}
+	for _, path := range files {
+		if err := os.WriteFile(path, []byte("old"), 0664); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	registry := midas.Registry{
+		"post-1": files["kept"],
+		"post-9": files["stale"],
+		// Entries of types missing from the config are kept.
+		"page-2": filepath.Join(root, "content", "pages", "page.md"),
+	}
+
+	site := newTestSite(t, midas.Site{
+		RootDir: root,
+		CollectionTypes: map[string]midas.ModelSettings{
+			"post": {OutputDir: "content/posts", Output: midas.OutputMarkdown},
+		},
+		SingleTypes: map[string]midas.ModelSettings{
+			"homepage": {OutputDir: "data"},
+			"footer":   {OutputDir: "data"},
+		},
+	}, registry)
+
+	payload := func(model string, id int, title string) midas.Payload {
+		payload, err := strapi.ParsePayload([]byte(fmt.Sprintf(`{
+    "event": "entry.update",
+    "createdAt": "2022-01-01T10:10:10.000Z",
+    "model": "%s",
+    "entry": {"id": %d, "Title": "%s", "Content": "New"}
+  }`, model, id, title)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return payload
+	}
+
+	result, err := site.Sync([]midas.Payload{
+		payload("post", 1, "Hello"),
+		payload("post", 2, "Created"),
+		payload("homepage", 1, "Home"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testing_utils.AssertEquals(t, len(result.Removed), 2, "Removed")
+	testing_utils.AssertTable(t, map[string][]interface{}{
+		"Updated":            {result.Updated, 3},
+		"Removed entry":      {result.Removed[0], files["stale"]},
+		"Removed single":     {result.Removed[1], files["single"]},
+		"Kept path":          {registry["post-1"], files["kept"]},
+		"Regenerated":        {readFile(t, files["kept"]) != "old", true},
+		"Created":            {registry["post-2"], filepath.Join(postsDir, "created.md")},
+		"Stale removed":      {fileExists(files["stale"]), false},
+		"Stale unregistered": {registry["post-9"], ""},
+		"Untracked kept":     {fileExists(files["untracked"]), true},
+		"Unconfigured kept":  {registry["page-2"] != "", true},
+		"Single written":     {fileExists(filepath.Join(dataDir, "homepage.json")), true},
+		"Single removed":     {fileExists(files["single"]), false},
+	})
+
+	t.Run("Rollback", func(t *testing.T) {
+		if err = site.Rollback(); err != nil {
+			t.Fatal(err)
+		}
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Stale restored":  {readFile(t, files["stale"]), "old"},
+			"Single restored": {readFile(t, files["single"]), "old"},
+			"Registry":        {registry["post-9"], files["stale"]},
+		})
+	})
+}
+
+func TestSiteService_SyncLocalizedSingles(t *testing.T) {
+	midas.Sanitizer = bluemonday.NewSanitizerService()
+
+	root := t.TempDir()
+	site := newTestSite(t, midas.Site{
+		RootDir: root,
+		Locales: midas.LocaleSettings{Default: "en", Mapping: map[string]string{"pl-PL": "pl"}},
+		SingleTypes: map[string]midas.ModelSettings{
+			"footer": {OutputDir: "data"},
+		},
+	}, midas.Registry{})
+
+	stale := filepath.Join(root, "data", "pl", "footer.json")
+	if err := os.MkdirAll(filepath.Dir(stale), 0775); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(stale, []byte("old"), 0664); err != nil {
+		t.Fatal(err)
+	}
+
+	payload, err := strapi.ParsePayload([]byte(`{
+    "event": "entry.update",
+    "createdAt": "2022-01-01T10:10:10.000Z",
+    "model": "footer",
+    "entry": {"id": 1, "Title": "Footer", "locale": "en"}
+  }`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := site.Sync([]midas.Payload{payload})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testing_utils.AssertEquals(t, len(result.Removed), 1, "Removed")
+	testing_utils.AssertTable(t, map[string][]interface{}{
+		"Removed single": {result.Removed[0], stale},
+		"Stale removed":  {fileExists(stale), false},
+		"Single written": {fileExists(filepath.Join(root, "data", "en", "footer.json")), true},
+	})
+}
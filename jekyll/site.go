@@ -0,0 +1,157 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package jekyll
+
+import (
+	"context"
+	"github.com/kovansky/midas"
+	"github.com/kovansky/midas/concurrent"
+	"github.com/kovansky/midas/content"
+	"github.com/rs/zerolog"
+	"gopkg.in/yaml.v3"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+var _ midas.SiteService = (*SiteService)(nil)
+
+// defaultCommand runs Jekyll from the bundle of the site.
+var defaultCommand = []string{"bundle", "exec", "jekyll"}
+
+// datePrefix matches the filenames of posts already prefixed with the date.
+var datePrefix = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}-`)
+
+type SiteService struct {
+	content.Service
+}
+
+func NewSiteService(config midas.Site) (midas.SiteService, error) {
+	service, err := content.NewService(config, Layout{})
+	if err != nil {
+		return nil, err
+	}
+
+	return SiteService{service}, nil
+}
+
+func (s SiteService) BuildSite(useCache bool, _ zerolog.Logger) error {
+	err := concurrent.Run(s.Site, "jekyll", func(ctx context.Context) *exec.Cmd {
+		return s.command(ctx, useCache, false)
+	})
+	if err != nil {
+		return err
+	}
+
+	if s.Site.BuildDrafts {
+		return s.BuildDrafts()
+	}
+
+	return nil
+}
+
+func (s SiteService) BuildDrafts() error {
+	out, err := s.command(context.Background(), false, true).CombinedOutput()
+	if err != nil {
+		return midas.Errorf(midas.ErrDraftsBuild, "jekyll draft build errored: %s\ncommand output: %s", err, out)
+	}
+
+	return nil
+}
+
+// command returns the Jekyll build command, writing to the output dir of the build. The main build runs in the
+// production environment, the drafts build in the draft environment, with drafts, future and unpublished entries.
+func (s SiteService) command(ctx context.Context, useCache, isDraft bool) *exec.Cmd {
+	command := s.Site.Jekyll.Command
+	if len(command) == 0 {
+		command = defaultCommand
+	}
+
+	output := s.Site.BuildOutput(isDraft)
+
+	arg := append(append([]string{}, command[1:]...), "build", "-d", output.Dir)
+
+	// In draft we never want to use cache to get the latest changes.
+	if !useCache || isDraft {
+		arg = append(arg, "--disable-disk-cache")
+	}
+
+	environment := "production"
+	if output.Drafts {
+		arg = append(arg, "--drafts", "--future", "--unpublished")
+		environment = output.Environment
+	}
+
+	cmd := exec.CommandContext(ctx, command[0], arg...)
+	cmd.Dir = s.Site.RootDir
+	cmd.Env = append(os.Environ(), "JEKYLL_ENV="+environment)
+
+	return cmd
+}
+
+// Layout writes the entries of the collection types as markdown files with YAML front matter, and the single types as
+// YAML data files (i.e. in the _data dir). Posts (entries written to the _posts dir) get the date prefix, and the
+// unpublished ones are written to the _drafts dir instead.
+type Layout struct{}
+
+func (l Layout) EntryPath(modelName string, model *midas.ModelSettings, payload midas.Payload) (string, string, string, error) {
+	if model.Output != "" && model.Output != midas.OutputMarkdown {
+		return "", "", "", midas.Errorf(midas.ErrSiteConfig, "output %s of model %s is not supported", model.Output, modelName)
+	}
+	if model.FrontMatter != "" && model.FrontMatter != midas.FrontMatterYAML {
+		return "", "", "", midas.Errorf(midas.ErrSiteConfig, "front matter format %s is not supported", model.FrontMatter)
+	}
+
+	filename, err := content.EntryFilename(modelName, model, payload)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	outputDir := model.OutputDir
+	if filepath.Base(filepath.Clean(outputDir)) != "_posts" {
+		return outputDir, filename, ".md", nil
+	}
+
+	if draft, _ := payload.Metadata()["draft"].(bool); draft {
+		return filepath.Join(filepath.Dir(filepath.Clean(outputDir)), "_drafts"), filename, ".md", nil
+	}
+
+	if !datePrefix.MatchString(filename) {
+		date, ok := content.FrontMatterValue(content.Date(payload.Entry())).(time.Time)
+		if !ok {
+			date = time.Now()
+		}
+
+		filename = date.Format("2006-01-02") + "-" + filename
+	}
+
+	return outputDir, filename, ".md", nil
+}
+
+// RenderEntry renders the entry as markdown. The title, date and published flag are added, unless the entry has
+// fields with the same names.
+func (l Layout) RenderEntry(model *midas.ModelSettings, payload midas.Payload) ([]byte, error) {
+	entry := payload.Entry()
+	draft, _ := payload.Metadata()["draft"].(bool)
+
+	return content.RenderMarkdown(model, payload, map[string]interface{}{
+		"title":     content.Title(model, entry),
+		"date":      content.Date(entry),
+		"published": !draft,
+	})
+}
+
+func (l Layout) SinglePath(modelName string, model *midas.ModelSettings, _ string) string {
+	return filepath.Join(model.OutputDir, modelName+".yml")
+}
+
+// RenderSingle renders the fields of the single type as YAML data file, with values passed through the HTML sanitizer.
+func (l Layout) RenderSingle(_ *midas.ModelSettings, payload midas.Payload) ([]byte, error) {
+	return yaml.Marshal(content.FrontMatterValue(content.SanitizeMap(payload.Entry())))
+}
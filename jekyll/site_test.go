@@ -0,0 +1,129 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package jekyll
+
+import (
+	"fmt"
+	"github.com/kovansky/midas"
+	"github.com/kovansky/midas/bluemonday"
+	"github.com/kovansky/midas/concurrent"
+	"github.com/kovansky/midas/content"
+	"github.com/kovansky/midas/mock"
+	"github.com/kovansky/midas/testing_utils"
+	"github.com/rs/zerolog"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLayout(t *testing.T) {
+	midas.Sanitizer = bluemonday.NewSanitizerService()
+
+	layout := Layout{}
+	entry := map[string]interface{}{"id": 1, "Title": "First", "Content": "Hello", "createdAt": "2022-01-01T10:10:10.000Z"}
+	draft := mock.NewPayload("post", entry, "draft", true)
+
+	published := mock.NewPayload("post", map[string]interface{}{
+		"id": 1, "Title": "First", "createdAt": "2022-01-01T10:10:10.000Z", "publishedAt": "2022-01-03T10:10:10.000Z",
+	}, "draft", false)
+
+	posts := &midas.ModelSettings{OutputDir: "_posts"}
+
+	paths := map[string][]interface{}{
+		"Draft":      {draft, posts, filepath.Join("_drafts", "first.md")},
+		"Published":  {published, posts, filepath.Join("_posts", "2022-01-03-first.md")},
+		"Dated":      {published, &midas.ModelSettings{OutputDir: "_posts", Filename: "2021-12-31-{{ slug .Title }}"}, filepath.Join("_posts", "2021-12-31-first.md")},
+		"Collection": {draft, &midas.ModelSettings{OutputDir: "_recipes"}, filepath.Join("_recipes", "first.md")},
+	}
+
+	for name, test := range paths {
+		t.Run(name, func(t *testing.T) {
+			dir, filename, extension, err := layout.EntryPath("post", test[1].(*midas.ModelSettings), test[0].(midas.Payload))
+
+			testing_utils.AssertTable(t, map[string][]interface{}{
+				"Error": {err, nil},
+				"Path":  {filepath.Join(dir, filename+extension), test[2]},
+			})
+		})
+	}
+
+	t.Run("Markdown", func(t *testing.T) {
+		rendered, err := layout.RenderEntry(posts, draft)
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Error": {err, nil},
+			"Content": {string(rendered), `---
+Title: First
+createdAt: 2022-01-01T10:10:10Z
+date: 2022-01-01T10:10:10Z
+id: 1
+published: false
+title: First
+---
+
+Hello
+`},
+		})
+	})
+
+	t.Run("Single", func(t *testing.T) {
+		model := &midas.ModelSettings{OutputDir: "_data"}
+		rendered, err := layout.RenderSingle(model, draft)
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Path":  {layout.SinglePath("homepage", model, ""), filepath.Join("_data", "homepage.yml")},
+			"Error": {err, nil},
+			"Content": {string(rendered), `Content: Hello
+Title: First
+createdAt: 2022-01-01T10:10:10Z
+id: 1
+`},
+		})
+	})
+
+	t.Run("UnsupportedFrontMatter", func(t *testing.T) {
+		_, _, _, err := layout.EntryPath("page", &midas.ModelSettings{OutputDir: "_pages", FrontMatter: midas.FrontMatterTOML}, draft)
+
+		testing_utils.AssertEquals(t, midas.ErrorCode(err), midas.ErrSiteConfig, "Error code")
+	})
+}
+
+func TestSiteService_BuildSite(t *testing.T) {
+	midas.Concurrents = concurrent.NewList()
+
+	root := t.TempDir()
+	log := filepath.Join(root, "jekyll.log")
+
+	// The stub records the arguments and environment of each run instead of building the site.
+	stub := filepath.Join(root, "jekyll.sh")
+	script := "#!/bin/sh\necho \"$@ env=$JEKYLL_ENV\" >> " + log + "\n"
+	if err := os.WriteFile(stub, []byte(script), 0775); err != nil {
+		t.Fatal(err)
+	}
+
+	site := SiteService{content.Service{Site: midas.Site{
+		SiteName:    "jekyll",
+		RootDir:     root,
+		BuildDrafts: true,
+		Jekyll:      midas.JekyllSettings{Command: []string{stub}},
+	}}}
+
+	if err := site.BuildSite(true, zerolog.Nop()); err != nil {
+		t.Fatal(err)
+	}
+
+	runs, err := os.ReadFile(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := fmt.Sprintf("build -d %s env=production\n"+
+		"build -d %s --disable-disk-cache --drafts --future --unpublished env=development\n",
+		filepath.Join(root, "public"), filepath.Join(root, "publicDrafts"))
+
+	testing_utils.AssertEquals(t, string(runs), expected, "Runs")
+}
@@ -12,12 +12,19 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 )
 
+// dependenciesKey is the key of the dependencies in the registry file. Entry ids can't start with $, so it doesn't
+// collide with the entries.
+const dependenciesKey = "$dependencies"
+
 type RegistryService struct {
-	path     string
-	file     *os.File
-	registry midas.Registry
+	path         string
+	file         *os.File
+	registry     midas.Registry
+	dependencies midas.Dependencies
 
 	Site midas.Site
 }
@@ -63,17 +70,32 @@ func (r *RegistryService) readStorage() error {
 		return err
 	}
 
-	if len(data) == 0 {
-		r.registry = make(map[string]string)
+	r.registry = make(midas.Registry)
+	r.dependencies = make(midas.Dependencies)
 
+	if len(data) == 0 {
 		return nil
 	}
 
-	err = json.Unmarshal(data, &r.registry)
-	if err != nil {
+	var content map[string]json.RawMessage
+	if err = json.Unmarshal(data, &content); err != nil {
 		return err
 	}
 
+	for id, value := range content {
+		if id == dependenciesKey {
+			err = json.Unmarshal(value, &r.dependencies)
+		} else {
+			var filename string
+			err = json.Unmarshal(value, &filename)
+			r.registry[id] = filename
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -100,8 +122,16 @@ func (r *RegistryService) RemoveStorage() error {
 
 // Flush writes the working changes on registry to the file.
 func (r *RegistryService) Flush() error {
+	entries := make(map[string]interface{}, len(r.registry)+1)
+	for id, filename := range r.registry {
+		entries[id] = filename
+	}
+	if len(r.dependencies) > 0 {
+		entries[dependenciesKey] = r.dependencies
+	}
+
 	// Marshal the Registry into JSON
-	content, err := json.MarshalIndent(r.registry, "", "\t")
+	content, err := json.MarshalIndent(entries, "", "\t")
 	if err != nil {
 		return err
 	}
@@ -161,3 +191,105 @@ func (r *RegistryService) DeleteEntry(id string) error {
 	delete(r.registry, id)
 	return nil
 }
+
+// Snapshot returns a copy of all entries in the registry.
+func (r *RegistryService) Snapshot() (midas.Registry, error) {
+	snapshot := make(midas.Registry, len(r.registry))
+	for id, filename := range r.registry {
+		snapshot[id] = filename
+	}
+
+	return snapshot, nil
+}
+
+// SetDependency records the related entries referenced by the entry. Dependency without references is removed.
+func (r *RegistryService) SetDependency(id string, dependency midas.Dependency) error {
+	if len(dependency.References) == 0 {
+		delete(r.dependencies, id)
+		return nil
+	}
+
+	r.dependencies[id] = dependency
+	return nil
+}
+
+// ReadDependency returns the dependency of the entry.
+func (r *RegistryService) ReadDependency(id string) (midas.Dependency, error) {
+	dependency, ok := r.dependencies[id]
+	if !ok {
+		return midas.Dependency{}, midas.Errorf(midas.ErrRegistry, "dependency of %s doesn't exist", id)
+	}
+
+	return dependency, nil
+}
+
+// ReadDependents returns the dependencies of the entries referencing the related entry.
+func (r *RegistryService) ReadDependents(reference string) (midas.Dependencies, error) {
+	dependents := make(midas.Dependencies)
+
+	for id, dependency := range r.dependencies {
+		for _, dependencyReference := range dependency.References {
+			if dependencyReference == reference {
+				dependents[id] = dependency
+				break
+			}
+		}
+	}
+
+	return dependents, nil
+}
+
+// RenameEntries replaces the ids of the entries and dependencies with the new ones, e.g. when migrating to Strapi v5
+// document ids. Ids are mapped as "model-id" strings; the ids of localized entries, suffixed with the locale, are
+// renamed too. Returns the number of the renamed entries.
+func (r *RegistryService) RenameEntries(ids map[string]string) (int, error) {
+	rename := func(id string) (string, bool) {
+		if newId, ok := ids[id]; ok {
+			return newId, true
+		}
+
+		for oldId, newId := range ids {
+			if strings.HasPrefix(id, oldId+"-") {
+				return newId + strings.TrimPrefix(id, oldId), true
+			}
+		}
+
+		return id, false
+	}
+
+	registry := make(midas.Registry, len(r.registry))
+	renamed := 0
+	for id, filename := range r.registry {
+		newId, ok := rename(id)
+		if _, exists := registry[newId]; exists {
+			return 0, midas.Errorf(midas.ErrRegistry, "entry %s already exists", newId)
+		}
+
+		registry[newId] = filename
+		if ok {
+			renamed++
+		}
+	}
+
+	dependencies := make(midas.Dependencies, len(r.dependencies))
+	for id, dependency := range r.dependencies {
+		if newId, ok := ids[dependency.Model+"-"+dependency.Id]; ok {
+			dependency.Id = strings.TrimPrefix(newId, dependency.Model+"-")
+		}
+
+		references := make([]string, len(dependency.References))
+		for i, reference := range dependency.References {
+			references[i], _ = rename(reference)
+		}
+		sort.Strings(references)
+		dependency.References = references
+
+		newId, _ := rename(id)
+		dependencies[newId] = dependency
+	}
+
+	r.registry = registry
+	r.dependencies = dependencies
+
+	return renamed, nil
+}
@@ -145,6 +145,56 @@ func TestRegistryService_DeleteEntry(t *testing.T) {
 	}
 }
 
+func TestRegistryService_SetDependency(t *testing.T) {
+	type args struct {
+		id         string
+		dependency midas.Dependency
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantLen int
+	}{
+		{"First", args{"post-1", midas.Dependency{Model: "post", Id: "1", References: []string{"author-1", "category-2"}}}, 1},
+		{"Second", args{"post-2", midas.Dependency{Model: "post", Id: "2", References: []string{"author-1"}}}, 2},
+		{"Update", args{"post-2", midas.Dependency{Model: "post", Id: "2", References: []string{"author-3"}}}, 2},
+		{"Remove", args{"post-3", midas.Dependency{}}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := r.SetDependency(tt.args.id, tt.args.dependency); err != nil {
+				t.Errorf("SetDependency() error = %v", err)
+			}
+			if len(r.dependencies) != tt.wantLen {
+				t.Errorf("SetDependency() len = %v, wantLen %v", len(r.dependencies), tt.wantLen)
+			}
+		})
+	}
+}
+
+func TestRegistryService_ReadDependents(t *testing.T) {
+	tests := []struct {
+		name      string
+		reference string
+		wantLen   int
+	}{
+		{"Single", "author-1", 1},
+		{"Updated", "author-3", 1},
+		{"Unreferenced", "author-2", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dependents, err := r.ReadDependents(tt.reference)
+			if err != nil {
+				t.Errorf("ReadDependents() error = %v", err)
+			}
+			if len(dependents) != tt.wantLen {
+				t.Errorf("ReadDependents() len = %v, wantLen %v", len(dependents), tt.wantLen)
+			}
+		})
+	}
+}
+
 func TestRegistryService_Flush(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -168,6 +218,72 @@ func TestRegistryService_Flush(t *testing.T) {
 			if len(r.registry) != lenBefore {
 				t.Errorf("Flush() len before flush = %v, after flush = %v", lenBefore, len(r.registry))
 			}
+
+			if dependency, err := r.ReadDependency("post-1"); err != nil || len(dependency.References) != 2 {
+				t.Errorf("Flush() dependency = %v, error = %v", dependency, err)
+			}
+		})
+	}
+}
+
+func TestRegistryService_Snapshot(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		{"Snapshot", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snapshot, err := r.Snapshot()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Snapshot() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if len(snapshot) != len(r.registry) {
+				t.Errorf("Snapshot() len = %v, registry len %v", len(snapshot), len(r.registry))
+			}
+
+			// Snapshot has to be a copy, not affected by later changes.
+			snapshot["snapshot-only"] = "snapshot-only.html"
+			if _, err := r.ReadEntry("snapshot-only"); err == nil {
+				t.Errorf("Snapshot() modifies the registry")
+			}
+		})
+	}
+}
+
+func TestRegistryService_RenameEntries(t *testing.T) {
+	registry := &RegistryService{
+		registry: midas.Registry{"post-1-en": "post-1.en.html", "post-2": "post-2.html", "post-12": "post-12.html"},
+		dependencies: midas.Dependencies{
+			"post-2": {Model: "post", Id: "2", References: []string{"author-3", "category-1"}},
+		},
+	}
+
+	renamed, err := registry.RenameEntries(map[string]string{"post-1": "post-a1", "post-2": "post-b2", "author-3": "author-c3"})
+	if err != nil {
+		t.Fatalf("RenameEntries() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		got  interface{}
+		want interface{}
+	}{
+		{"Renamed", renamed, 2},
+		{"Localized", registry.registry["post-a1-en"], "post-1.en.html"},
+		{"Plain", registry.registry["post-b2"], "post-2.html"},
+		{"Unmapped", registry.registry["post-12"], "post-12.html"},
+		{"Dependency id", registry.dependencies["post-b2"].Id, "b2"},
+		{"Reference", registry.dependencies["post-b2"].References[0], "author-c3"},
+		{"Unmapped reference", registry.dependencies["post-b2"].References[1], "category-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("RenameEntries() %s = %v, want %v", tt.name, tt.got, tt.want)
+			}
 		})
 	}
 }
@@ -0,0 +1,32 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package mock
+
+import (
+	"github.com/kovansky/midas"
+)
+
+type Deployment struct {
+	DeployFn func() error
+	PlanFn   func() (*midas.DeploymentPlan, error)
+
+	Site     midas.Site
+	Settings midas.DeploymentSettings
+	IsDraft  bool
+}
+
+func NewDeployment(site midas.Site, settings midas.DeploymentSettings, isDraft bool) *Deployment {
+	return &Deployment{Site: site, Settings: settings, IsDraft: isDraft}
+}
+
+func (d *Deployment) Deploy() error {
+	return d.DeployFn()
+}
+
+func (d *Deployment) Plan() (*midas.DeploymentPlan, error) {
+	return d.PlanFn()
+}
@@ -0,0 +1,67 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package mock
+
+import (
+	"encoding/json"
+	"github.com/kovansky/midas"
+)
+
+var _ midas.Payload = (*Payload)(nil)
+
+// Payload is the update event payload of the entry, with the metadata given directly (i.e. draft or locale) instead
+// of derived from the webhook of a provider.
+type Payload struct {
+	EntryMap    map[string]interface{}
+	MetadataMap map[string]interface{}
+}
+
+// NewPayload returns the payload of the model entry, with the model and entry id in the metadata. Additional metadata
+// is given as key-value pairs.
+func NewPayload(model string, entry map[string]interface{}, metadata ...interface{}) *Payload {
+	payload := &Payload{
+		EntryMap:    entry,
+		MetadataMap: map[string]interface{}{"event": "entry.update", "model": model, "entryId": entry["id"]},
+	}
+
+	for i := 0; i+1 < len(metadata); i += 2 {
+		payload.MetadataMap[metadata[i].(string)] = metadata[i+1]
+	}
+
+	return payload
+}
+
+func (p *Payload) Event() string {
+	return p.MetadataMap["event"].(string)
+}
+
+func (p *Payload) Metadata() map[string]interface{} {
+	return p.MetadataMap
+}
+
+func (p *Payload) Entry() map[string]interface{} {
+	return p.EntryMap
+}
+
+func (p *Payload) SetEntry(entry map[string]interface{}) {
+	p.EntryMap = entry
+}
+
+func (p *Payload) Raw() interface{} {
+	return p
+}
+
+func (p *Payload) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Model interface{}            `json:"model"`
+		Entry map[string]interface{} `json:"entry"`
+	}{p.MetadataMap["model"], p.EntryMap})
+}
+
+func (p *Payload) UnmarshalJSON(bytes []byte) error {
+	return json.Unmarshal(bytes, &p.EntryMap)
+}
@@ -20,6 +20,11 @@ type RegistryService struct {
 	ReadEntryFn     func(id string) (string, error)
 	UpdateEntryFn   func(id, newFilename string) error
 	DeleteEntryFn   func(id string) error
+	SnapshotFn      func() (midas.Registry, error)
+
+	SetDependencyFn  func(id string, dependency midas.Dependency) error
+	ReadDependencyFn func(id string) (midas.Dependency, error)
+	ReadDependentsFn func(reference string) (midas.Dependencies, error)
 
 	Site midas.Site
 }
@@ -63,3 +68,19 @@ func (r *RegistryService) UpdateEntry(id, newFilename string) error {
 func (r *RegistryService) DeleteEntry(id string) error {
 	return r.DeleteEntryFn(id)
 }
+
+func (r *RegistryService) Snapshot() (midas.Registry, error) {
+	return r.SnapshotFn()
+}
+
+func (r *RegistryService) SetDependency(id string, dependency midas.Dependency) error {
+	return r.SetDependencyFn(id, dependency)
+}
+
+func (r *RegistryService) ReadDependency(id string) (midas.Dependency, error) {
+	return r.ReadDependencyFn(id)
+}
+
+func (r *RegistryService) ReadDependents(reference string) (midas.Dependencies, error) {
+	return r.ReadDependentsFn(reference)
+}
@@ -21,6 +21,11 @@ type SiteService struct {
 	UpdateEntryFn        func(payload midas.Payload) (string, error)
 	DeleteEntryFn        func(payload midas.Payload) (string, error)
 	UpdateSingleFn       func(payload midas.Payload) (string, error)
+	DeleteSingleFn       func(payload midas.Payload) (string, error)
+	MirrorMediaFn        func(payload midas.MediaPayload) ([]string, error)
+	CommitFn             func() error
+	RollbackFn           func() error
+	SyncFn               func(payloads []midas.Payload) (midas.SyncResult, error)
 }
 
 func NewSiteService() *SiteService {
@@ -50,3 +55,23 @@ func (s *SiteService) DeleteEntry(payload midas.Payload) (string, error) {
 func (s *SiteService) UpdateSingle(payload midas.Payload) (string, error) {
 	return s.UpdateSingleFn(payload)
 }
+
+func (s *SiteService) DeleteSingle(payload midas.Payload) (string, error) {
+	return s.DeleteSingleFn(payload)
+}
+
+func (s *SiteService) MirrorMedia(payload midas.MediaPayload) ([]string, error) {
+	return s.MirrorMediaFn(payload)
+}
+
+func (s *SiteService) Commit() error {
+	return s.CommitFn()
+}
+
+func (s *SiteService) Rollback() error {
+	return s.RollbackFn()
+}
+
+func (s *SiteService) Sync(payloads []midas.Payload) (midas.SyncResult, error) {
+	return s.SyncFn(payloads)
+}
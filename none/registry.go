@@ -52,3 +52,19 @@ func (r RegistryService) UpdateEntry(_, _ string) error {
 func (r RegistryService) DeleteEntry(_ string) error {
 	return nil
 }
+
+func (r RegistryService) Snapshot() (midas.Registry, error) {
+	return midas.Registry{}, nil
+}
+
+func (r RegistryService) SetDependency(_ string, _ midas.Dependency) error {
+	return nil
+}
+
+func (r RegistryService) ReadDependency(_ string) (midas.Dependency, error) {
+	return midas.Dependency{}, nil
+}
+
+func (r RegistryService) ReadDependents(_ string) (midas.Dependencies, error) {
+	return midas.Dependencies{}, nil
+}
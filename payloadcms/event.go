@@ -0,0 +1,48 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package payloadcms
+
+type Event int64
+
+const (
+	Undefined Event = iota
+	Create
+	Update
+	Delete
+)
+
+const (
+	// AfterChange is the hook called after the document is created or updated.
+	AfterChange = "afterChange"
+	// AfterDelete is the hook called after the document is deleted.
+	AfterDelete = "afterDelete"
+)
+
+var toString = map[Event]string{
+	Undefined: "",
+	Create:    "Create",
+	Update:    "Update",
+	Delete:    "Delete",
+}
+
+// ParseEvent returns the event of the hook and its operation (create or update, for afterChange hooks).
+func ParseEvent(hook, operation string) Event {
+	switch {
+	case hook == AfterChange && operation == "create":
+		return Create
+	case hook == AfterChange && operation == "update":
+		return Update
+	case hook == AfterDelete:
+		return Delete
+	default:
+		return Undefined
+	}
+}
+
+func (event Event) String() string {
+	return toString[event]
+}
@@ -0,0 +1,168 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package payloadcms
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/kovansky/midas"
+	"time"
+)
+
+var _ midas.Payload = (*Payload)(nil)
+
+// Payload is the payload sent by the afterChange and afterDelete hooks of Payload CMS collections and globals. The
+// document of the hook is the entry of the payload.
+type Payload struct {
+	hook      string
+	operation string
+	event     Event
+	CreatedAt time.Time
+	// Model is the slug of the collection or the global.
+	Model string
+	// Global tells if the document is a global (single type).
+	Global   bool
+	id       interface{}
+	locale   string
+	metadata map[string]interface{}
+	entry    map[string]interface{}
+}
+
+// ParsePayload parses the hook payload.
+func ParsePayload(json []byte) (*Payload, error) {
+	payload := Payload{}
+	if err := payload.UnmarshalJSON(json); err != nil {
+		return nil, err
+	}
+
+	if payload.event == Undefined {
+		return nil, midas.Errorf(midas.ErrInvalid, "hook %s with operation %s is not supported", payload.hook, payload.operation)
+	}
+
+	if payload.Model == "" {
+		return nil, midas.Errorf(midas.ErrInvalid, "payload has no collection nor global")
+	}
+
+	payload.CreatedAt = time.Now()
+	if updatedAt, ok := payload.entry["updatedAt"].(string); ok {
+		if parsed, err := time.Parse(time.RFC3339, updatedAt); err == nil {
+			payload.CreatedAt = parsed
+		}
+	}
+
+	payload.createMetadataMap()
+
+	return &payload, nil
+}
+
+func (p Payload) Event() string {
+	return p.event.String()
+}
+
+func (p Payload) Metadata() map[string]interface{} {
+	return p.metadata
+}
+
+func (p *Payload) createMetadataMap() {
+	asMap := make(map[string]interface{})
+
+	asMap["event"] = p.event
+	asMap["createdAt"] = p.CreatedAt
+	asMap["model"] = p.Model
+	asMap["global"] = p.Global
+
+	asMap["entryId"] = p.id
+	if id, ok := p.entry["id"]; ok && id != nil {
+		asMap["entryId"] = id
+	}
+
+	// With drafts enabled, the documents have the status.
+	asMap["published"] = false
+	if status, ok := p.entry["_status"].(string); ok {
+		asMap["published"] = status == "published"
+		asMap["draft"] = status == "draft"
+	}
+
+	// With localization enabled, the locales of the document share the id.
+	if p.locale != "" {
+		asMap["locale"] = p.locale
+		asMap["translationKey"] = fmt.Sprintf("%s-%v", p.Model, asMap["entryId"])
+	}
+
+	p.metadata = asMap
+}
+
+func (p Payload) Entry() map[string]interface{} {
+	return p.entry
+}
+
+func (p *Payload) SetEntry(entry map[string]interface{}) {
+	p.entry = entry
+	p.createMetadataMap()
+}
+
+func (p Payload) Raw() interface{} {
+	return p
+}
+
+func (p Payload) MarshalJSON() ([]byte, error) {
+	data := struct {
+		Event      string                 `json:"event"`
+		Operation  string                 `json:"operation,omitempty"`
+		Collection string                 `json:"collection,omitempty"`
+		Global     string                 `json:"global,omitempty"`
+		Locale     string                 `json:"locale,omitempty"`
+		Id         interface{}            `json:"id,omitempty"`
+		Doc        map[string]interface{} `json:"doc,omitempty"`
+	}{
+		Event:     p.hook,
+		Operation: p.operation,
+		Locale:    p.locale,
+		Id:        p.id,
+		Doc:       p.entry,
+	}
+
+	if p.Global {
+		data.Global = p.Model
+	} else {
+		data.Collection = p.Model
+	}
+
+	return json.Marshal(data)
+}
+
+func (p *Payload) UnmarshalJSON(bytes []byte) error {
+	var data struct {
+		Event      string                 `json:"event"`
+		Operation  string                 `json:"operation"`
+		Collection string                 `json:"collection"`
+		Global     string                 `json:"global"`
+		Locale     string                 `json:"locale"`
+		Id         interface{}            `json:"id"`
+		Doc        map[string]interface{} `json:"doc"`
+	}
+	if err := json.Unmarshal(bytes, &data); err != nil {
+		return err
+	}
+
+	newPayload := Payload{
+		hook:      data.Event,
+		operation: data.Operation,
+		event:     ParseEvent(data.Event, data.Operation),
+		Model:     data.Collection,
+		id:        data.Id,
+		locale:    data.Locale,
+		entry:     data.Doc,
+	}
+
+	if data.Global != "" {
+		newPayload.Model, newPayload.Global = data.Global, true
+	}
+
+	*p = newPayload
+	return nil
+}
@@ -0,0 +1,79 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package payloadcms
+
+import (
+	"github.com/kovansky/midas"
+	"github.com/kovansky/midas/testing_utils"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func readSample(t *testing.T, name string) []byte {
+	data, err := os.ReadFile(filepath.Join("testdata", name+".json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return data
+}
+
+func TestParsePayload(t *testing.T) {
+	t.Run("Create", func(t *testing.T) {
+		payload, err := ParsePayload(readSample(t, "create"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		author, _ := payload.Entry()["author"].(map[string]interface{})
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Event":           {payload.Event(), Create.String()},
+			"Model":           {payload.Metadata()["model"], "posts"},
+			"Global":          {payload.Metadata()["global"], false},
+			"Entry id":        {payload.Metadata()["entryId"], "65f1c2a9e4b0a1d2c3e4f5a6"},
+			"Published":       {payload.Metadata()["published"], true},
+			"Draft":           {payload.Metadata()["draft"], false},
+			"Locale":          {payload.Metadata()["locale"], "en"},
+			"Translation key": {payload.Metadata()["translationKey"], "posts-65f1c2a9e4b0a1d2c3e4f5a6"},
+			"Title":           {payload.Entry()["title"], "Hello World"},
+			"Author":          {author["name"], "Jane"},
+			"Created at":      {payload.CreatedAt.Format("2006-01-02"), "2026-03-02"},
+		})
+	})
+
+	t.Run("Global", func(t *testing.T) {
+		payload, err := ParsePayload(readSample(t, "global"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Event":  {payload.Event(), Update.String()},
+			"Model":  {payload.Metadata()["model"], "header"},
+			"Global": {payload.Global, true},
+		})
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		payload, err := ParsePayload(readSample(t, "delete"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Event":    {payload.Event(), Delete.String()},
+			"Entry id": {payload.Metadata()["entryId"], "65f1c2a9e4b0a1d2c3e4f5a6"},
+		})
+	})
+
+	t.Run("UnsupportedHook", func(t *testing.T) {
+		_, err := ParsePayload([]byte(`{"event": "beforeChange", "operation": "create", "collection": "posts", "doc": {}}`))
+		testing_utils.AssertEquals(t, midas.ErrorCode(err), midas.ErrInvalid, "Error code")
+	})
+}
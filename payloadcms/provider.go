@@ -0,0 +1,48 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package payloadcms
+
+import (
+	"github.com/kovansky/midas"
+	"github.com/rs/zerolog"
+)
+
+var _ midas.Provider = (*Provider)(nil)
+
+// Provider maps the Payload CMS hooks to the content changes of the sites.
+type Provider struct{}
+
+func NewProvider() *Provider {
+	return &Provider{}
+}
+
+// Changes returns the change of the hook document. Globals are changed as single types.
+func (p Provider) Changes(site midas.Site, _ midas.SiteService, webhook midas.Webhook, _ zerolog.Logger) ([]midas.Change, error) {
+	payload, err := ParsePayload(webhook.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, _, err = site.AcceptModel(payload.Model); err != nil {
+		return nil, err
+	}
+
+	var action midas.Action
+
+	switch payload.event {
+	case Create:
+		action = midas.ActionCreate
+	case Update:
+		action = midas.ActionUpdate
+	case Delete:
+		action = midas.ActionDelete
+	default:
+		return nil, midas.Errorf(midas.ErrInvalid, "event %s is invalid", payload.Event())
+	}
+
+	return []midas.Change{{Action: action, Payload: payload}}, nil
+}
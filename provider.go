@@ -0,0 +1,85 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package midas
+
+import (
+	"github.com/rs/zerolog"
+	"net/http"
+)
+
+// Action is the change of the site content requested by the webhook.
+type Action int
+
+const (
+	ActionCreate Action = iota + 1
+	ActionUpdate
+	ActionDelete
+	// ActionMedia mirrors the media library change (the MediaPayload) into the media directory of the site.
+	ActionMedia
+)
+
+func (a Action) String() string {
+	switch a {
+	case ActionCreate:
+		return "create"
+	case ActionUpdate:
+		return "update"
+	case ActionDelete:
+		return "delete"
+	case ActionMedia:
+		return "media"
+	default:
+		return "undefined"
+	}
+}
+
+// Change is the content change of one entry. Entries of the single types are changed as singles.
+type Change struct {
+	Action  Action
+	Payload Payload
+}
+
+// MediaPayload is the payload of the media library change. The media is mirrored by the site service, so the mirrored
+// files are committed or rolled back with the content.
+type MediaPayload interface {
+	Payload
+	// Mirror mirrors the media into the media directory of the site. Each file is passed to stage before it's changed.
+	// Returns the paths of the changed files.
+	Mirror(site Site, stage func(path string) error) ([]string, error)
+}
+
+// Webhook is the request sent by the CMS.
+type Webhook struct {
+	Header http.Header
+	Body   []byte
+}
+
+// Provider maps the webhooks of the CMS to the content changes of the sites, so the content of any static site
+// generator can be changed the same way.
+type Provider interface {
+	// Changes parses the webhook of the site and returns the content changes it requests. No changes mean the site is
+	// not rebuilt (i.e. only the draft changed). The site service gives access to the registry of the site (i.e. to
+	// find the entries referencing the changed one).
+	Changes(site Site, siteService SiteService, webhook Webhook, log zerolog.Logger) ([]Change, error)
+}
+
+// SyncProvider is the provider able to fetch all entries of the site from the CMS, used for the content resync.
+type SyncProvider interface {
+	Provider
+	// FetchAll fetches all entries of the collection and single types of the site, as the payloads of update events.
+	FetchAll(site Site) ([]Payload, error)
+}
+
+// NewChanges returns the changes of the payloads, all with the same action.
+func NewChanges(action Action, payloads ...Payload) []Change {
+	changes := make([]Change, 0, len(payloads))
+	for _, payload := range payloads {
+		changes = append(changes, Change{Action: action, Payload: payload})
+	}
+
+	return changes
+}
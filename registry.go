@@ -14,6 +14,19 @@ package midas
 // "1" would be a key and "sample-post.html" would be a value.
 type Registry map[string]string
 
+// Dependency records the related entries referenced by the entry, so the entry can be regenerated when any of them
+// changes.
+type Dependency struct {
+	Model  string `json:"model"`
+	Id     string `json:"id"`
+	Locale string `json:"locale,omitempty"`
+	// References are the related entries, as <model>-<id>.
+	References []string `json:"references"`
+}
+
+// Dependencies hold the dependencies of the entries. It's structure is Id => Dependency.
+type Dependencies map[string]Dependency
+
 type RegistryService interface {
 	OpenStorage() error
 	CloseStorage()
@@ -24,4 +37,12 @@ type RegistryService interface {
 	ReadEntry(id string) (string, error)
 	UpdateEntry(id, newFilename string) error
 	DeleteEntry(id string) error
+	// Snapshot returns a copy of all entries of the registry.
+	Snapshot() (Registry, error)
+	// SetDependency records the related entries referenced by the entry. Dependency without references is removed.
+	SetDependency(id string, dependency Dependency) error
+	// ReadDependency returns the dependency of the entry.
+	ReadDependency(id string) (Dependency, error)
+	// ReadDependents returns the dependencies of the entries referencing the related entry (<model>-<id>).
+	ReadDependents(reference string) (Dependencies, error)
 }
@@ -147,7 +147,7 @@ func (c *Client) RemoveEmptyDirs() error {
 
 // UploadNewFile creates a source file in the remote server.
 func (c *Client) UploadNewFile(filePath string, file *os.File) error {
-	absolutePath := filepath.ToSlash(filepath.Clean(filepath.Join(c.rootDir, filePath)))
+	absolutePath := c.absolutePath(filePath)
 	dir := filepath.ToSlash(filepath.Dir(absolutePath))
 
 	// First we need to create all parent directories
@@ -174,7 +174,7 @@ func (c *Client) UploadNewFile(filePath string, file *os.File) error {
 
 // RemoveFile removes a file from the remote server.
 func (c *Client) RemoveFile(filePath string) error {
-	absolutePath := filepath.ToSlash(filepath.Clean(filepath.Join(c.rootDir, filePath)))
+	absolutePath := c.absolutePath(filePath)
 
 	err := c.sftpClient.Remove(absolutePath)
 	if err != nil {
@@ -184,6 +184,11 @@ func (c *Client) RemoveFile(filePath string) error {
 	return nil
 }
 
+// absolutePath returns the remote path of a file (relative to website root).
+func (c *Client) absolutePath(filePath string) string {
+	return filepath.ToSlash(filepath.Clean(filepath.Join(c.rootDir, filePath)))
+}
+
 // authenticationMethod returns the authentication method name and the authentication method slice based on the provided SFTP configuration.
 //
 // If method field was not configured, it checks if password field was set and uses it; otherwise it uses "none" method.
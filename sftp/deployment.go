@@ -26,21 +26,7 @@ type Deployment struct {
 
 func New(site midas.Site, deploymentSettings midas.DeploymentSettings, isDraft bool) (midas.Deployment, error) {
 	// Get build destination directory
-	var publicPath = filepath.Join(site.RootDir, "public")
-
-	if !isDraft && site.OutputSettings.Build != "" {
-		if filepath.IsAbs(site.OutputSettings.Build) {
-			publicPath = site.OutputSettings.Build
-		} else {
-			publicPath = filepath.Join(site.RootDir, site.OutputSettings.Build)
-		}
-	} else if isDraft && site.OutputSettings.Draft != "" {
-		if filepath.IsAbs(site.OutputSettings.Draft) {
-			publicPath = site.OutputSettings.Draft
-		} else {
-			publicPath = filepath.Join(site.RootDir, site.OutputSettings.Draft)
-		}
-	}
+	var publicPath = site.PublicPath(isDraft)
 
 	sftpClient := *NewClient(deploymentSettings.SFTP)
 
@@ -91,6 +77,27 @@ func (d *Deployment) Deploy() error {
 	return nil
 }
 
+// Plan returns the changes Deploy would make on the remote SFTP server, without changing anything.
+func (d *Deployment) Plan() (*midas.DeploymentPlan, error) {
+	// Retrieve local files.
+	walker, err := d.retrieveFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	fileMap, err := d.getFileMap(walker)
+	if err != nil {
+		return nil, err
+	}
+
+	remoteFiles, err := d.remoteFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	return walk.Plan(fileMap.Diff(remoteFiles), d.sftpClient.absolutePath), nil
+}
+
 // syncFile performs a file operation.
 func (d *Deployment) syncFile(operation walk.FileOperation) error {
 	switch operation.Type {
@@ -6,7 +6,11 @@
 
 package midas
 
-import "github.com/rs/zerolog"
+import (
+	"fmt"
+	"github.com/rs/zerolog"
+	"path/filepath"
+)
 
 type Site struct {
 	SiteName string `json:"siteName"`
@@ -18,12 +22,137 @@ type Site struct {
 	BuildDrafts bool   `json:"buildDrafts,default=false"`
 	DraftsUrl   string `json:"draftsUrl"`
 
+	// MediaUrl is the base URL of the CMS media, used to download media referenced by relative URLs.
+	MediaUrl string `json:"mediaUrl,omitempty"`
+	// MediaDir is the directory of the site (relative to the root dir) mirroring the CMS media library, i.e.
+	// static/uploads. Media events are accepted only if it's set.
+	MediaDir string `json:"mediaDir,omitempty"`
+
 	Registry        RegistrySettings         `json:"registry"`
 	CollectionTypes map[string]ModelSettings `json:"collectionTypes"`
 	SingleTypes     map[string]ModelSettings `json:"singleTypes"`
 
 	Deployment       DeploymentSettings `json:"deployment"`
 	DraftsDeployment DeploymentSettings `json:"draftsDeployment"`
+
+	Deployments        []DeploymentSettings `json:"deployments,omitempty"`
+	DeploymentStrategy string               `json:"deploymentStrategy,omitempty"` // Can be: sequential, parallel. Default: sequential
+
+	Snapshots SnapshotSettings `json:"snapshots"`
+
+	Locales LocaleSettings `json:"locales"`
+
+	Strapi   StrapiSettings   `json:"strapi"`
+	Directus DirectusSettings `json:"directus"`
+	Generic  GenericSettings  `json:"generic"`
+
+	Eleventy EleventySettings `json:"eleventy"`
+	Jekyll   JekyllSettings   `json:"jekyll"`
+	Zola     ZolaSettings     `json:"zola"`
+	Command  CommandSettings  `json:"command"`
+}
+
+// StrapiSettings configures the Strapi REST API, used to fetch the entries with populated relations and components.
+// Entries are fetched only if the URL is set.
+type StrapiSettings struct {
+	Url   string `json:"url,omitempty"`
+	Token string `json:"token,omitempty"`
+	// Version is the major version of Strapi (4 or 5). Default: detected from the webhook payload, 4 when fetching
+	// the entries without a payload (i.e. resync).
+	Version int `json:"version,omitempty"`
+}
+
+// DirectusSettings configures the Directus REST API, used to fetch the whole items, as the update events contain only
+// the changed fields.
+type DirectusSettings struct {
+	Url   string `json:"url,omitempty"`
+	Token string `json:"token,omitempty"`
+}
+
+// GenericSettings map the webhook body of the generic provider to the entry. The fields are the paths of the values in
+// the body, in JSONPath ($.data.id) or jq (.data.id) notation: keys separated by dots, and [n] indexes of arrays.
+type GenericSettings struct {
+	// Event is the path of the event type.
+	Event string `json:"event"`
+	// Events map the values of the event type to the events: create, update or delete. Values missing from the map
+	// have to be the events themselves.
+	Events map[string]string `json:"events,omitempty"`
+	Model  string            `json:"model"`
+	Id     string            `json:"id"`
+	// Published is the path of the publication state. Entries with false, null, empty or missing value (i.e.
+	// publishedAt date of a draft) are drafts. Default: all entries are published.
+	Published string `json:"published,omitempty"`
+	// Entry is the path of the entry object. Default: the whole body.
+	Entry string `json:"entry,omitempty"`
+}
+
+// EleventySettings configures the Eleventy (11ty) build.
+type EleventySettings struct {
+	// Command is the command running Eleventy, with its arguments. Default: npx @11ty/eleventy.
+	Command []string `json:"command,omitempty"`
+	// Input is the input directory of Eleventy (relative to the root dir). Default: the Eleventy config or root dir.
+	Input string `json:"input,omitempty"`
+}
+
+// JekyllSettings configures the Jekyll build.
+type JekyllSettings struct {
+	// Command is the command running Jekyll, with its arguments. Default: bundle exec jekyll.
+	Command []string `json:"command,omitempty"`
+}
+
+// ZolaSettings configures the Zola build.
+type ZolaSettings struct {
+	// Command is the command running Zola, with its arguments. Default: zola.
+	Command []string `json:"command,omitempty"`
+}
+
+// CommandSettings configures the build command of the command service, for the generators without their own service.
+// The command has to build the site to the build directory of the output settings.
+type CommandSettings struct {
+	Command string   `json:"command"`
+	Args    []string `json:"args,omitempty"`
+	// Env are the environment variables added to the environment of Midas.
+	Env map[string]string `json:"env,omitempty"`
+	// WorkDir is the working directory of the command, absolute or relative to the root dir. Default: the root dir.
+	WorkDir string `json:"workDir,omitempty"`
+	// Drafts is the command building the drafts to the draft directory of the output settings, run if building drafts
+	// is enabled. Default: drafts are not built.
+	Drafts *CommandSettings `json:"drafts,omitempty"`
+}
+
+const (
+	LocaleDirectory = "directory"
+	LocaleFilename  = "filename"
+)
+
+// LocaleSettings configures where the entries in different locales (i.e. from Strapi i18n) are placed.
+type LocaleSettings struct {
+	// Mapping maps CMS locale codes to site language keys, i.e. "en-US": "en". Unmapped locales are used as they are.
+	Mapping map[string]string `json:"mapping,omitempty"`
+	// Strategy can be: directory (content/<lang>/posts) or filename (<slug>.<lang>.md). Default: directory
+	Strategy string `json:"strategy,omitempty"`
+	// Default is the default language of the site. With filename strategy, its entries have no language suffix.
+	Default string `json:"default,omitempty"`
+}
+
+// Language returns the site language key of the CMS locale.
+func (s LocaleSettings) Language(locale string) string {
+	if lang, ok := s.Mapping[locale]; ok {
+		return lang
+	}
+
+	return locale
+}
+
+// Enabled tells if the site is multilingual, i.e. any locale settings are set.
+func (s LocaleSettings) Enabled() bool {
+	return s.Strategy != "" || s.Default != "" || len(s.Mapping) > 0
+}
+
+type SnapshotSettings struct {
+	Enabled  bool   `json:"enabled,default=false"`
+	Location string `json:"location,omitempty"` // Default: .midas-snapshots
+	Keep     int    `json:"keep,omitempty"`     // Number of kept snapshots. Default: 10
 }
 
 type OutputSettings struct {
@@ -32,12 +161,140 @@ type OutputSettings struct {
 	DraftEnvironment string `json:"draftEnvironment,omitempty"`
 }
 
+// PublicPath returns the absolute path of the directory the site (or drafts, if isDraft is true) is built to.
+func (s Site) PublicPath(isDraft bool) string {
+	var publicPath = filepath.Join(s.RootDir, "public")
+	if isDraft {
+		publicPath = filepath.Join(s.RootDir, "publicDrafts")
+	}
+
+	if !isDraft && s.OutputSettings.Build != "" {
+		if filepath.IsAbs(s.OutputSettings.Build) {
+			publicPath = s.OutputSettings.Build
+		} else {
+			publicPath = filepath.Join(s.RootDir, s.OutputSettings.Build)
+		}
+	} else if isDraft && s.OutputSettings.Draft != "" {
+		if filepath.IsAbs(s.OutputSettings.Draft) {
+			publicPath = s.OutputSettings.Draft
+		} else {
+			publicPath = filepath.Join(s.RootDir, s.OutputSettings.Draft)
+		}
+	}
+
+	return publicPath
+}
+
+// BuildOutput describes the build of the site (or drafts, if isDraft is true) by the output settings, for the site
+// services to pass to the generator.
+type BuildOutput struct {
+	// Dir is the absolute path of the directory the site is built to.
+	Dir string
+	// Drafts tells if the drafts (as well as future and expired entries) are built.
+	Drafts bool
+	// Environment is the environment of the drafts build. Empty for the main build.
+	Environment string
+	// BaseUrl is the base URL of the drafts build. Empty if not set, so the URL from the generator config is used.
+	BaseUrl string
+}
+
+// BuildOutput returns the output of the site build, or of the drafts build if isDraft is true.
+func (s Site) BuildOutput(isDraft bool) BuildOutput {
+	output := BuildOutput{Dir: s.PublicPath(isDraft), Drafts: isDraft}
+
+	if isDraft {
+		output.Environment = s.OutputSettings.DraftEnvironment
+		if output.Environment == "" {
+			output.Environment = "development"
+		}
+
+		output.BaseUrl = s.DraftsUrl
+	}
+
+	return output
+}
+
+// EnabledDeployments returns all enabled deployments of the site, starting with the deployment and draftsDeployment
+// settings, followed by the deployments list. Deployments without a name are named after their setting key or
+// position in the list.
+func (s Site) EnabledDeployments() []DeploymentSettings {
+	var deployments []DeploymentSettings
+
+	if s.Deployment.Enabled {
+		deployment := s.Deployment
+		deployment.Draft = false
+		if deployment.Name == "" {
+			deployment.Name = "deployment"
+		}
+
+		deployments = append(deployments, deployment)
+	}
+
+	if s.DraftsDeployment.Enabled {
+		deployment := s.DraftsDeployment
+		deployment.Draft = true
+		if deployment.Name == "" {
+			deployment.Name = "draftsDeployment"
+		}
+
+		deployments = append(deployments, deployment)
+	}
+
+	for i, deployment := range s.Deployments {
+		if !deployment.Enabled {
+			continue
+		}
+
+		if deployment.Name == "" {
+			deployment.Name = fmt.Sprintf("deployments[%d]", i)
+		}
+
+		deployments = append(deployments, deployment)
+	}
+
+	return deployments
+}
+
+const (
+	OutputHTML     = "html"
+	OutputMarkdown = "markdown"
+	OutputJSON     = "json"
+	OutputMDX      = "mdx"
+
+	FrontMatterYAML = "yaml"
+	FrontMatterTOML = "toml"
+	FrontMatterJSON = "json"
+)
+
 type ModelSettings struct {
 	ArchetypePath string `json:"archetypePath,omitempty"`
 	OutputDir     string `json:"outputDir,omitempty"`
-	Fields        struct {
+	// Output is the format of generated entries: html (from the archetype), markdown (front matter and body, without
+	// an archetype), mdx (markdown for MDX, Astro only) or json (data file with the entry fields, Eleventy and Astro
+	// only). Default: html, markdown for Eleventy, Jekyll, Zola and Astro.
+	Output string `json:"output,omitempty"`
+	// FrontMatter is the format of the front matter in markdown output: yaml, toml or json. Default: yaml.
+	FrontMatter string `json:"frontMatter,omitempty"`
+	// Filename is the Go template of the entry filename (without the extension), i.e. {{ slug .Entry.slug }} or
+	// {{ .Entry.id }}. Default: {{ slug .Title }}.
+	Filename string `json:"filename,omitempty"`
+	// ApiPath is the path of the type in Strapi REST API. Default: model name with "s" suffix for collection types,
+	// model name for single types.
+	ApiPath string `json:"apiPath,omitempty"`
+	// Populate is the populate spec of the Strapi REST API request: the value of populate parameter (i.e. * or
+	// author,categories) or the query string (i.e. populate[author][fields][0]=name). Default: *.
+	Populate string `json:"populate,omitempty"`
+	// Relations maps the relation fields of the model to the related models, i.e. author: writer. Entries are
+	// regenerated when the related entries change.
+	Relations map[string]string `json:"relations,omitempty"`
+	// Bundle makes the entries of collection types leaf bundles (<slug>/index.md), with the referenced media
+	// downloaded into the bundle.
+	Bundle bool `json:"bundle,omitempty"`
+	Fields struct {
 		Title *string   `json:"title,omitempty"`
 		HTML  *[]string `json:"html,omitempty"`
+		// Body is the field used as the content of markdown output. Default: Content.
+		Body *string `json:"body,omitempty"`
 	} `json:"fields"`
 }
 
@@ -48,9 +305,43 @@ type RegistrySettings struct {
 
 type SiteService interface {
 	GetRegistryService() (RegistryService, error)
+	// BuildSite builds the site, and the drafts if enabled. Failure of the drafts build (after the site was built) is
+	// reported with the ErrDraftsBuild code.
 	BuildSite(useCache bool, log zerolog.Logger) error
 	CreateEntry(payload Payload) (string, error)
 	UpdateEntry(payload Payload) (string, error)
 	DeleteEntry(payload Payload) (string, error)
 	UpdateSingle(payload Payload) (string, error)
+	DeleteSingle(payload Payload) (string, error)
+	// MirrorMedia mirrors the media library change into the media directory of the site, as part of the content
+	// changes. Returns the paths of the changed files.
+	MirrorMedia(payload MediaPayload) ([]string, error)
+	// Commit accepts the content changes made since the last commit or rollback.
+	Commit() error
+	// Rollback restores the content and registry entries changed since the last commit or rollback, i.e. when the
+	// build with the changes failed.
+	Rollback() error
+	// Sync regenerates the content of all entries of the site (i.e. fetched from the CMS), and removes the content of
+	// the entries missing from them.
+	Sync(payloads []Payload) (SyncResult, error)
+}
+
+// SyncResult summarizes the content resync of the site.
+type SyncResult struct {
+	// Updated is the number of regenerated entries.
+	Updated int `json:"updated"`
+	// Removed are the paths of removed content.
+	Removed []string `json:"removed"`
+}
+
+// AcceptModel returns the settings of the model and tells if it's a single type. Models which are neither single nor
+// collection types of the site are not accepted.
+func (s Site) AcceptModel(model string) (ModelSettings, bool, error) {
+	if settings, ok := s.SingleTypes[model]; ok {
+		return settings, true, nil
+	} else if settings, ok = s.CollectionTypes[model]; ok {
+		return settings, false, nil
+	}
+
+	return ModelSettings{}, false, Errorf(ErrUnaccepted, "model %s is not accepted", model)
 }
@@ -0,0 +1,358 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package snapshot
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"github.com/kovansky/midas"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	defaultLocation = ".midas-snapshots"
+	defaultKeep     = 10
+	extension       = ".tar.gz"
+
+	// Layout of the archive.
+	buildDir     = "build"
+	draftsDir    = "drafts"
+	registryFile = "registry.json"
+)
+
+// Snapshot describes an archived build of the site.
+type Snapshot struct {
+	Id        string    `json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+	Size      int64     `json:"size"`
+}
+
+// Service manages the build snapshots of a site. Each snapshot is a tarball holding the build output, drafts output
+// (if drafts are built) and the registry entries.
+type Service struct {
+	site midas.Site
+	dir  string
+}
+
+func NewService(site midas.Site) *Service {
+	dir := site.Snapshots.Location
+	if dir == "" {
+		dir = defaultLocation
+	}
+
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(site.RootDir, dir)
+	}
+
+	return &Service{site: site, dir: dir}
+}
+
+// Create archives the current build output of the site together with the registry entries, and removes the oldest
+// snapshots above the configured limit. Returns the id of the new snapshot.
+func (s *Service) Create(registry midas.Registry) (string, error) {
+	if err := os.MkdirAll(s.dir, 0775); err != nil {
+		return "", err
+	}
+
+	id := s.newId()
+	archivePath := s.path(id)
+
+	// Write to a temporary file first, so incomplete archives are never listed.
+	file, err := os.Create(archivePath + ".tmp")
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = file.Close()
+		_ = os.Remove(archivePath + ".tmp")
+	}()
+
+	if err = s.write(file, registry); err != nil {
+		return "", err
+	}
+
+	if err = file.Close(); err != nil {
+		return "", err
+	}
+
+	if err = os.Rename(archivePath+".tmp", archivePath); err != nil {
+		return "", err
+	}
+
+	if err = s.prune(); err != nil {
+		return id, err
+	}
+
+	return id, nil
+}
+
+// List returns all snapshots of the site, newest first.
+func (s *Service) List() ([]Snapshot, error) {
+	entries, err := os.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return []Snapshot{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	snapshots := []Snapshot{}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), extension) {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+
+		snapshots = append(snapshots, Snapshot{
+			Id:        strings.TrimSuffix(entry.Name(), extension),
+			CreatedAt: info.ModTime(),
+			Size:      info.Size(),
+		})
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Id > snapshots[j].Id
+	})
+
+	return snapshots, nil
+}
+
+// Extract unpacks the snapshot into dest directory and returns the site config, which output settings point to the
+// extracted files, so it can be deployed. If the snapshot holds no drafts, draft deployments are disabled.
+//
+// Extracted files get the current modification time, so deployment targets comparing the modification times upload
+// them again.
+func (s *Service) Extract(id, dest string) (midas.Site, error) {
+	site := s.site
+
+	if id == "" || id != filepath.Base(id) || strings.HasPrefix(id, ".") {
+		return site, midas.Errorf(midas.ErrInvalid, "invalid snapshot id %s", id)
+	}
+
+	file, err := os.Open(s.path(id))
+	if os.IsNotExist(err) {
+		return site, midas.Errorf(midas.ErrNotFound, "snapshot %s doesn't exist", id)
+	} else if err != nil {
+		return site, err
+	}
+	defer func(file *os.File) {
+		_ = file.Close()
+	}(file)
+
+	if err = extract(file, dest); err != nil {
+		return site, fmt.Errorf("could not extract snapshot %s: %v", id, err)
+	}
+
+	// Build directory has to exist, even if the build was empty.
+	if err = os.MkdirAll(filepath.Join(dest, buildDir), 0775); err != nil {
+		return site, err
+	}
+
+	site.OutputSettings.Build = filepath.Join(dest, buildDir)
+	site.OutputSettings.Draft = filepath.Join(dest, draftsDir)
+
+	if _, err = os.Stat(site.OutputSettings.Draft); os.IsNotExist(err) {
+		site.DraftsDeployment.Enabled = false
+
+		var deployments []midas.DeploymentSettings
+		for _, deployment := range site.Deployments {
+			if !deployment.Draft {
+				deployments = append(deployments, deployment)
+			}
+		}
+		site.Deployments = deployments
+	}
+
+	return site, nil
+}
+
+// newId returns the id for a new snapshot, based on the current time.
+func (s *Service) newId() string {
+	base := time.Now().UTC().Format("20060102T150405Z")
+
+	id := base
+	for i := 1; ; i++ {
+		if _, err := os.Stat(s.path(id)); os.IsNotExist(err) {
+			return id
+		}
+
+		id = fmt.Sprintf("%s-%d", base, i)
+	}
+}
+
+func (s *Service) path(id string) string {
+	return filepath.Join(s.dir, id+extension)
+}
+
+// write writes the archive of build output and registry to w.
+func (s *Service) write(w io.Writer, registry midas.Registry) error {
+	gzipWriter := gzip.NewWriter(w)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	if err := addDir(tarWriter, s.site.PublicPath(false), buildDir); err != nil {
+		return err
+	}
+
+	if s.site.BuildDrafts {
+		draftsPath := s.site.PublicPath(true)
+		if _, err := os.Stat(draftsPath); err == nil {
+			if err = addDir(tarWriter, draftsPath, draftsDir); err != nil {
+				return err
+			}
+		}
+	}
+
+	content, err := json.MarshalIndent(registry, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	if err = tarWriter.WriteHeader(&tar.Header{
+		Name:    registryFile,
+		Mode:    0664,
+		Size:    int64(len(content)),
+		ModTime: time.Now(),
+	}); err != nil {
+		return err
+	}
+
+	if _, err = tarWriter.Write(content); err != nil {
+		return err
+	}
+
+	if err = tarWriter.Close(); err != nil {
+		return err
+	}
+
+	return gzipWriter.Close()
+}
+
+// prune removes the oldest snapshots above the configured limit.
+func (s *Service) prune() error {
+	keep := s.site.Snapshots.Keep
+	if keep <= 0 {
+		keep = defaultKeep
+	}
+
+	snapshots, err := s.List()
+	if err != nil {
+		return err
+	}
+
+	for i := keep; i < len(snapshots); i++ {
+		if err = os.Remove(s.path(snapshots[i].Id)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addDir adds all files from the directory to the archive, under the prefix directory.
+func addDir(tarWriter *tar.Writer, dir, prefix string) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = prefix + "/" + filepath.ToSlash(rel)
+
+		if err = tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer func(file *os.File) {
+			_ = file.Close()
+		}(file)
+
+		_, err = io.Copy(tarWriter, file)
+		return err
+	})
+}
+
+// extract unpacks the archive into dest directory. Files which would be placed outside of dest are rejected.
+func extract(r io.Reader, dest string) error {
+	gzipReader, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer func(gzipReader *gzip.Reader) {
+		_ = gzipReader.Close()
+	}(gzipReader)
+
+	dest = filepath.Clean(dest)
+	tarReader := tar.NewReader(gzipReader)
+
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		target := filepath.Join(dest, filepath.FromSlash(header.Name))
+		if !strings.HasPrefix(target, dest+string(os.PathSeparator)) {
+			return fmt.Errorf("illegal file path %s", header.Name)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err = os.MkdirAll(target, 0775); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err = extractFile(tarReader, target); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func extractFile(r io.Reader, target string) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0775); err != nil {
+		return err
+	}
+
+	file, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	defer func(file *os.File) {
+		_ = file.Close()
+	}(file)
+
+	_, err = io.Copy(file, r)
+	return err
+}
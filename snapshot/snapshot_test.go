@@ -0,0 +1,146 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package snapshot
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"github.com/kovansky/midas"
+	"github.com/kovansky/midas/testing_utils"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestService(t *testing.T) {
+	site := midas.Site{
+		RootDir:          t.TempDir(),
+		BuildDrafts:      true,
+		DraftsDeployment: midas.DeploymentSettings{Enabled: true, Target: "mock"},
+		Snapshots:        midas.SnapshotSettings{Enabled: true, Keep: 2},
+	}
+	writeFile(t, filepath.Join(site.PublicPath(false), "index.html"), "home")
+	writeFile(t, filepath.Join(site.PublicPath(false), "posts", "first", "index.html"), "first")
+
+	service := NewService(site)
+
+	t.Run("Create", func(t *testing.T) {
+		id, err := service.Create(midas.Registry{"1": "first.html"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		snapshots, err := service.List()
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"List error":      {err, nil},
+			"Snapshots count": {len(snapshots), 1},
+			"Snapshot id":     {snapshots[0].Id, id},
+		})
+	})
+
+	t.Run("Extract", func(t *testing.T) {
+		// Change the build after the snapshot, so we know the snapshot content is deployed.
+		writeFile(t, filepath.Join(site.PublicPath(false), "index.html"), "broken")
+
+		snapshots, _ := service.List()
+		dest := t.TempDir()
+
+		extracted, err := service.Extract(snapshots[0].Id, dest)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		registry, _ := os.ReadFile(filepath.Join(dest, registryFile))
+		var parsedRegistry midas.Registry
+		err = json.Unmarshal(registry, &parsedRegistry)
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Public path":         {extracted.PublicPath(false), filepath.Join(dest, buildDir)},
+			"index.html":          {readFile(t, filepath.Join(extracted.PublicPath(false), "index.html")), "home"},
+			"first/index.html":    {readFile(t, filepath.Join(extracted.PublicPath(false), "posts", "first", "index.html")), "first"},
+			"Registry error":      {err, nil},
+			"Registry entry":      {parsedRegistry["1"], "first.html"},
+			"Drafts deployment":   {extracted.DraftsDeployment.Enabled, false},
+			"Original site build": {site.PublicPath(false), filepath.Join(site.RootDir, "public")},
+		})
+	})
+
+	t.Run("Prune", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			if _, err := service.Create(midas.Registry{}); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		snapshots, err := service.List()
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"List error":      {err, nil},
+			"Snapshots count": {len(snapshots), 2},
+			"Newest first":    {snapshots[0].Id > snapshots[1].Id, true},
+		})
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := service.Extract("20000101T000000Z", t.TempDir())
+
+		testing_utils.AssertEquals(t, midas.ErrorCode(err), midas.ErrNotFound, "Error code")
+	})
+
+	t.Run("InvalidId", func(t *testing.T) {
+		_, err := service.Extract("../secret", t.TempDir())
+
+		testing_utils.AssertEquals(t, midas.ErrorCode(err), midas.ErrInvalid, "Error code")
+	})
+}
+
+func TestExtract_IllegalPath(t *testing.T) {
+	var archive bytes.Buffer
+	gzipWriter := gzip.NewWriter(&archive)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	content := []byte("evil")
+	_ = tarWriter.WriteHeader(&tar.Header{Name: "../evil.txt", Mode: 0664, Size: int64(len(content)), Typeflag: tar.TypeReg})
+	_, _ = tarWriter.Write(content)
+	_ = tarWriter.Close()
+	_ = gzipWriter.Close()
+
+	dest := filepath.Join(t.TempDir(), "dest")
+	err := extract(&archive, dest)
+
+	_, statErr := os.Stat(filepath.Join(filepath.Dir(dest), "evil.txt"))
+
+	testing_utils.AssertTable(t, map[string][]interface{}{
+		"Rejected":        {err != nil, true},
+		"File not exists": {os.IsNotExist(statErr), true},
+	})
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0775); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0664); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+
+	return string(content)
+}
@@ -0,0 +1,498 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package strapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/kovansky/midas"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// pageSize is the number of entries fetched per request when listing the entries.
+const pageSize = 100
+
+// Client fetches the entries from the Strapi REST API.
+type Client struct {
+	baseUrl *url.URL
+	token   string
+	version int
+	client  *http.Client
+}
+
+func NewClient(settings midas.StrapiSettings) (*Client, error) {
+	if settings.Url == "" {
+		return nil, midas.Errorf(midas.ErrSiteConfig, "strapi url is not set")
+	}
+
+	baseUrl, err := url.Parse(strings.TrimSuffix(settings.Url, "/"))
+	if err != nil || baseUrl.Scheme == "" || baseUrl.Host == "" {
+		return nil, midas.Errorf(midas.ErrSiteConfig, "strapi url %s is invalid", settings.Url)
+	}
+
+	version := settings.Version
+	switch version {
+	case 0:
+		version = V4
+	case V4, V5:
+	default:
+		return nil, midas.Errorf(midas.ErrSiteConfig, "strapi version %d is not supported", version)
+	}
+
+	return &Client{
+		baseUrl: baseUrl,
+		token:   settings.Token,
+		version: version,
+		client:  &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// PopulatePayload re-fetches the entry of the payload with relations, components and dynamic zones populated
+// according to the model settings, and replaces the payload entry with it. The fields of the webhook entry missing
+// from the fetched one are kept.
+func (c *Client) PopulatePayload(payload midas.Payload, model midas.ModelSettings, isSingle bool) error {
+	strapiPayload, ok := payload.(*Payload)
+	if !ok {
+		return midas.Errorf(midas.ErrInternal, "payload is not a strapi payload")
+	}
+
+	id := strapiPayload.entryId()
+	if id == nil && !isSingle {
+		return midas.Errorf(midas.ErrInvalid, "entry has no id")
+	}
+
+	locale, _ := strapiPayload.entry["locale"].(string)
+	// Unpublished Strapi v5 documents have only the draft version.
+	draft, _ := strapiPayload.metadata["draft"].(bool)
+
+	fetched, err := c.fetchModelEntry(strapiPayload.Model, fmt.Sprint(id), locale, model, isSingle, draft)
+	if err != nil {
+		return err
+	}
+
+	entry := make(map[string]interface{})
+	for key, value := range strapiPayload.entry {
+		entry[key] = value
+	}
+	for key, value := range fetched {
+		entry[key] = value
+	}
+
+	strapiPayload.SetEntry(entry)
+	strapiPayload.createMetadataMap()
+
+	return nil
+}
+
+// FetchPayload fetches the entry of the dependency, populated according to the model settings, as the payload of an
+// update event.
+func (c *Client) FetchPayload(dependency midas.Dependency, model midas.ModelSettings, isSingle bool) (midas.Payload, error) {
+	entry, err := c.fetchModelEntry(dependency.Model, dependency.Id, dependency.Locale, model, isSingle, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.newUpdatePayload(dependency.Model, entry), nil
+}
+
+// FetchAll fetches all entries of the collection and single types of the site, as the payloads of update events. On
+// multilingual sites (with locales settings), the entries in all locales are fetched.
+func (c *Client) FetchAll(site midas.Site) ([]midas.Payload, error) {
+	multilingual := site.Locales.Enabled()
+	var payloads []midas.Payload
+
+	modelNames := make([]string, 0, len(site.CollectionTypes))
+	for modelName := range site.CollectionTypes {
+		modelNames = append(modelNames, modelName)
+	}
+	sort.Strings(modelNames)
+
+	for _, modelName := range modelNames {
+		entries, err := c.fetchCollection(modelName, site.CollectionTypes[modelName], multilingual)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			payloads = append(payloads, c.newUpdatePayload(modelName, entry))
+		}
+	}
+
+	modelNames = modelNames[:0]
+	for modelName := range site.SingleTypes {
+		modelNames = append(modelNames, modelName)
+	}
+	sort.Strings(modelNames)
+
+	for _, modelName := range modelNames {
+		model := site.SingleTypes[modelName]
+
+		entry, err := c.fetchSingle(modelName, "", model)
+		if midas.ErrorCode(err) == midas.ErrNotFound {
+			// Single type without content.
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		payloads = append(payloads, c.newUpdatePayload(modelName, entry))
+
+		if !multilingual {
+			continue
+		}
+
+		localizations, _ := entry["localizations"].([]interface{})
+		for _, localization := range localizations {
+			localization, _ := localization.(map[string]interface{})
+			locale, ok := localization["locale"].(string)
+			if !ok || locale == "" {
+				continue
+			}
+
+			localized, err := c.fetchSingle(modelName, locale, model)
+			if err != nil {
+				return nil, err
+			}
+
+			payloads = append(payloads, c.newUpdatePayload(modelName, localized))
+		}
+	}
+
+	for _, payload := range payloads {
+		payload.(*Payload).SetLocales(site.Locales)
+	}
+
+	return payloads, nil
+}
+
+// DocumentIds returns the ids of the entries of the model mapped to their document ids, both as the "model-id" strings
+// used in the registry. The published and draft versions in all locales are included. Supported by Strapi v5 only.
+func (c *Client) DocumentIds(modelName string, model midas.ModelSettings, isSingle bool) (map[string]string, error) {
+	if c.version != V5 {
+		return nil, midas.Errorf(midas.ErrSiteConfig, "document ids are supported by strapi v5 only")
+	}
+
+	var entries []map[string]interface{}
+
+	for _, draft := range []bool{false, true} {
+		if isSingle {
+			// Other locales of the single type are listed in the localizations.
+			model.Populate = "localizations"
+
+			entry, err := c.fetchModelEntry(modelName, "", "", model, true, draft)
+			if midas.ErrorCode(err) == midas.ErrNotFound {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+
+			entries = append(entries, entry)
+			localizations, _ := entry["localizations"].([]interface{})
+			for _, localization := range localizations {
+				if localization, ok := localization.(map[string]interface{}); ok {
+					entries = append(entries, localization)
+				}
+			}
+
+			continue
+		}
+
+		// Only the ids are needed.
+		model.Populate = "fields[0]=documentId"
+
+		modelEntries, err := c.fetchModelEntries(modelName, model, true, draft)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, modelEntries...)
+	}
+
+	documentIds := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		id, documentId := entry["id"], entry["documentId"]
+		if id == nil || documentId == nil {
+			continue
+		}
+
+		documentIds[fmt.Sprintf("%s-%v", modelName, id)] = fmt.Sprintf("%s-%v", modelName, documentId)
+	}
+
+	return documentIds, nil
+}
+
+// fetchCollection fetches all entries of the collection type, drafts included. Strapi v5 lists the published and draft
+// versions of the documents separately, so the drafts of published documents are skipped.
+func (c *Client) fetchCollection(modelName string, model midas.ModelSettings, allLocales bool) ([]map[string]interface{}, error) {
+	entries, err := c.fetchModelEntries(modelName, model, allLocales, false)
+	if err != nil || c.version != V5 {
+		return entries, err
+	}
+
+	drafts, err := c.fetchModelEntries(modelName, model, allLocales, true)
+	if err != nil {
+		return nil, err
+	}
+
+	published := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		published[fmt.Sprintf("%v-%v", entry["documentId"], entry["locale"])] = true
+	}
+
+	for _, draft := range drafts {
+		if !published[fmt.Sprintf("%v-%v", draft["documentId"], draft["locale"])] {
+			entries = append(entries, draft)
+		}
+	}
+
+	return entries, nil
+}
+
+// fetchSingle fetches the single type entry in the locale, or its draft if it's not published (Strapi v5).
+func (c *Client) fetchSingle(modelName, locale string, model midas.ModelSettings) (map[string]interface{}, error) {
+	entry, err := c.fetchModelEntry(modelName, "", locale, model, true, false)
+	if midas.ErrorCode(err) == midas.ErrNotFound && c.version == V5 {
+		return c.fetchModelEntry(modelName, "", locale, model, true, true)
+	}
+
+	return entry, err
+}
+
+// fetchModelEntries fetches all entries of the collection type, page by page.
+func (c *Client) fetchModelEntries(modelName string, model midas.ModelSettings, allLocales, draft bool) ([]map[string]interface{}, error) {
+	apiPath := model.ApiPath
+	if apiPath == "" {
+		apiPath = modelName + "s"
+	}
+
+	query, err := c.entryQuery(model, draft)
+	if err != nil {
+		return nil, err
+	}
+
+	query.Set("pagination[pageSize]", fmt.Sprint(pageSize))
+	if allLocales {
+		query.Set("locale", "all")
+		if c.version == V5 {
+			query.Set("locale", "*")
+		}
+	}
+
+	var entries []map[string]interface{}
+
+	for page := 1; ; page++ {
+		query.Set("pagination[page]", fmt.Sprint(page))
+
+		data, meta, err := c.fetch(apiPath, query)
+		if err != nil {
+			return nil, err
+		}
+
+		list, ok := flatten(data).([]interface{})
+		if !ok {
+			return nil, midas.Errorf(midas.ErrInternal, "strapi response of %s is not a list", apiPath)
+		}
+
+		for _, entry := range list {
+			if entry, ok := entry.(map[string]interface{}); ok {
+				entries = append(entries, entry)
+			}
+		}
+
+		if len(list) == 0 || page >= meta.Pagination.PageCount {
+			return entries, nil
+		}
+	}
+}
+
+// newUpdatePayload creates the payload of the entry update event.
+func (c *Client) newUpdatePayload(modelName string, entry map[string]interface{}) *Payload {
+	payload := &Payload{
+		event:     Update,
+		CreatedAt: time.Now(),
+		Model:     modelName,
+		Version:   c.version,
+		entry:     entry,
+	}
+	payload.createMetadataMap()
+
+	return payload
+}
+
+// fetchModelEntry fetches the entry of the model in the locale. Collection type entries are fetched by id (document id
+// for Strapi v5, which is shared by all translations of the document). Draft flag selects the draft version of Strapi
+// v5 documents.
+func (c *Client) fetchModelEntry(modelName, id, locale string, model midas.ModelSettings, isSingle, draft bool) (map[string]interface{}, error) {
+	apiPath := model.ApiPath
+	if apiPath == "" {
+		apiPath = modelName
+		if !isSingle {
+			apiPath += "s"
+		}
+	}
+
+	query, err := c.entryQuery(model, draft)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isSingle {
+		apiPath = fmt.Sprintf("%s/%s", apiPath, id)
+	}
+	if locale != "" {
+		query.Set("locale", locale)
+	}
+
+	return c.FetchEntry(apiPath, query)
+}
+
+// FetchEntry fetches the entry from the API path (relative to /api) and returns it flattened to the webhook entry
+// format.
+func (c *Client) FetchEntry(apiPath string, query url.Values) (map[string]interface{}, error) {
+	data, _, err := c.fetch(apiPath, query)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, ok := flatten(data).(map[string]interface{})
+	if !ok {
+		return nil, midas.Errorf(midas.ErrNotFound, "entry %s not found in strapi", apiPath)
+	}
+
+	return entry, nil
+}
+
+// meta is the metadata of the REST API response.
+type meta struct {
+	Pagination struct {
+		Page      int `json:"page"`
+		PageCount int `json:"pageCount"`
+	} `json:"pagination"`
+}
+
+// fetch requests the API path (relative to /api) and returns the response data and metadata.
+func (c *Client) fetch(apiPath string, query url.Values) (interface{}, meta, error) {
+	var body struct {
+		Data interface{} `json:"data"`
+		Meta meta        `json:"meta"`
+	}
+
+	endpoint := *c.baseUrl
+	endpoint.Path += "/api/" + strings.Trim(apiPath, "/")
+	endpoint.RawQuery = query.Encode()
+
+	request, err := http.NewRequest(http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return nil, body.Meta, err
+	}
+
+	request.Header.Set("Accept", "application/json")
+	if c.token != "" {
+		request.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	response, err := c.client.Do(request)
+	if err != nil {
+		return nil, body.Meta, midas.Errorf(midas.ErrInternal, "could not fetch %s from strapi: %v", apiPath, err)
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	switch {
+	case response.StatusCode == http.StatusNotFound:
+		return nil, body.Meta, midas.Errorf(midas.ErrNotFound, "entry %s not found in strapi", apiPath)
+	case response.StatusCode != http.StatusOK:
+		return nil, body.Meta, midas.Errorf(midas.ErrInternal, "strapi responded to %s with %s", apiPath, response.Status)
+	}
+
+	if err = json.NewDecoder(response.Body).Decode(&body); err != nil {
+		return nil, body.Meta, midas.Errorf(midas.ErrInternal, "strapi response malformed: %v", err)
+	}
+
+	return body.Data, body.Meta, nil
+}
+
+// entryQuery returns the query parameters populating the entry according to the model settings, and selecting its
+// version. Strapi v4 returns the drafts along with the published entries, v5 returns the published or draft version
+// of the document.
+func (c *Client) entryQuery(model midas.ModelSettings, draft bool) (url.Values, error) {
+	query, err := populateQuery(model.Populate)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case c.version != V5:
+		// Drafts are fetched too, as the webhooks are sent for them.
+		query.Set("publicationState", "preview")
+	case draft:
+		query.Set("status", "draft")
+	default:
+		query.Set("status", "published")
+	}
+
+	return query, nil
+}
+
+// populateQuery converts the populate spec to the query parameters. The spec is either the query string, or the value
+// of the populate parameter.
+func populateQuery(spec string) (url.Values, error) {
+	switch {
+	case spec == "":
+		return url.Values{"populate": {"*"}}, nil
+	case strings.Contains(spec, "="):
+		query, err := url.ParseQuery(spec)
+		if err != nil {
+			return nil, midas.Errorf(midas.ErrSiteConfig, "populate spec %s is invalid", spec)
+		}
+
+		return query, nil
+	default:
+		return url.Values{"populate": {spec}}, nil
+	}
+}
+
+// flatten converts the REST API response data to the webhook entry format: the attributes are merged with the id, and
+// the relations are unwrapped from their data objects.
+func flatten(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		if data, ok := value["data"]; ok && len(value) == 1 {
+			return flatten(data)
+		}
+
+		flattened := make(map[string]interface{})
+		for key, nested := range value {
+			if key == "attributes" {
+				continue
+			}
+			flattened[key] = flatten(nested)
+		}
+
+		if attributes, ok := value["attributes"].(map[string]interface{}); ok {
+			for key, nested := range attributes {
+				flattened[key] = flatten(nested)
+			}
+		}
+
+		return flattened
+	case []interface{}:
+		flattened := make([]interface{}, 0, len(value))
+		for _, nested := range value {
+			flattened = append(flattened, flatten(nested))
+		}
+
+		return flattened
+	default:
+		return value
+	}
+}
@@ -0,0 +1,296 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package strapi
+
+import (
+	"fmt"
+	"github.com/kovansky/midas"
+	"github.com/kovansky/midas/testing_utils"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const populatedPost = `{
+  "data": {
+    "id": 1,
+    "attributes": {
+      "Title": "Hello World",
+      "publishedAt": null,
+      "author": {"data": {"id": 3, "attributes": {"name": "John"}}},
+      "categories": {"data": [{"id": 5, "attributes": {"name": "News"}}]},
+      "blocks": [{"id": 1, "__component": "shared.quote", "author": {"data": null}}]
+    }
+  },
+  "meta": {}
+}`
+
+func TestClient_PopulatePayload(t *testing.T) {
+	var requested *http.Request
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r
+
+		switch r.URL.Path {
+		case "/api/posts/1", "/api/articles/1":
+			_, _ = fmt.Fprint(w, populatedPost)
+		case "/api/homepage":
+			_, _ = fmt.Fprint(w, `{"data": {"id": 1, "attributes": {"Title": "Home", "locale": "pl"}}, "meta": {}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = fmt.Fprint(w, `{"data": null, "error": {"status": 404}}`)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(midas.StrapiSettings{Url: server.URL + "/", Token: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parse := func(model string, entry string) midas.Payload {
+		payload, err := ParsePayload([]byte(fmt.Sprintf(`{
+    "event": "entry.update",
+    "createdAt": "2022-01-01T10:10:10.000Z",
+    "model": "%s",
+    "entry": %s
+  }`, model, entry)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return payload
+	}
+
+	t.Run("Collection", func(t *testing.T) {
+		payload := parse("post", `{"id": 1, "Title": "Hello", "publishedAt": null, "updatedBy": {"id": 1}}`)
+
+		if err := client.PopulatePayload(payload, midas.ModelSettings{}, false); err != nil {
+			t.Fatal(err)
+		}
+
+		entry := payload.Entry()
+		author, _ := entry["author"].(map[string]interface{})
+		categories, _ := entry["categories"].([]interface{})
+		blocks, _ := entry["blocks"].([]interface{})
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Path":          {requested.URL.Path, "/api/posts/1"},
+			"Authorization": {requested.Header.Get("Authorization"), "Bearer secret"},
+			"Populate":      {requested.URL.Query().Get("populate"), "*"},
+			"State":         {requested.URL.Query().Get("publicationState"), "preview"},
+			"Title":         {entry["Title"], "Hello World"},
+			"Kept field":    {entry["updatedBy"] != nil, true},
+			"Author":        {author["name"], "John"},
+			"Author id":     {author["id"], float64(3)},
+			"Categories":    {len(categories), 1},
+			"Blocks":        {len(blocks), 1},
+			"Empty author":  {blocks[0].(map[string]interface{})["author"], nil},
+			"Draft":         {payload.Metadata()["draft"], true},
+		})
+	})
+
+	t.Run("PopulateSpec", func(t *testing.T) {
+		payload := parse("post", `{"id": 1}`)
+		model := midas.ModelSettings{ApiPath: "articles", Populate: "populate[author][fields][0]=name"}
+
+		if err := client.PopulatePayload(payload, model, false); err != nil {
+			t.Fatal(err)
+		}
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Path":     {requested.URL.Path, "/api/articles/1"},
+			"Populate": {requested.URL.Query().Get("populate[author][fields][0]"), "name"},
+		})
+	})
+
+	t.Run("Single", func(t *testing.T) {
+		payload := parse("homepage", `{"id": 1, "Title": "Start", "locale": "pl"}`)
+
+		if err := client.PopulatePayload(payload, midas.ModelSettings{Populate: "deep"}, true); err != nil {
+			t.Fatal(err)
+		}
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Path":     {requested.URL.Path, "/api/homepage"},
+			"Locale":   {requested.URL.Query().Get("locale"), "pl"},
+			"Populate": {requested.URL.Query().Get("populate"), "deep"},
+			"Title":    {payload.Entry()["Title"], "Home"},
+		})
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		payload := parse("page", `{"id": 2}`)
+
+		err := client.PopulatePayload(payload, midas.ModelSettings{}, false)
+		testing_utils.AssertEquals(t, midas.ErrorCode(err), midas.ErrNotFound, "Error code")
+	})
+}
+
+func TestNewClient(t *testing.T) {
+	_, err := NewClient(midas.StrapiSettings{Url: "localhost"})
+	testing_utils.AssertEquals(t, midas.ErrorCode(err), midas.ErrSiteConfig, "Error code")
+}
+
+func TestClient_FetchAll(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/posts":
+			// Two pages, with the entries of all locales.
+			if r.URL.Query().Get("locale") != "all" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			page := r.URL.Query().Get("pagination[page]")
+			_, _ = fmt.Fprintf(w, `{"data": [{"id": %s, "attributes": {"Title": "Post %s", "locale": "en"}}], "meta": {"pagination": {"page": %s, "pageCount": 2}}}`, page, page, page)
+		case "/api/homepage":
+			if r.URL.Query().Get("locale") == "pl" {
+				_, _ = fmt.Fprint(w, `{"data": {"id": 2, "attributes": {"Title": "Start", "locale": "pl"}}}`)
+				return
+			}
+
+			_, _ = fmt.Fprint(w, `{"data": {"id": 1, "attributes": {"Title": "Home", "locale": "en", "localizations": {"data": [{"id": 2, "attributes": {"locale": "pl"}}]}}}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(midas.StrapiSettings{Url: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payloads, err := client.FetchAll(midas.Site{
+		Locales:         midas.LocaleSettings{Default: "en"},
+		CollectionTypes: map[string]midas.ModelSettings{"post": {}},
+		SingleTypes:     map[string]midas.ModelSettings{"homepage": {}, "footer": {}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testing_utils.AssertEquals(t, len(payloads), 4, "Payloads")
+	testing_utils.AssertTable(t, map[string][]interface{}{
+		"First post":       {payloads[0].Entry()["Title"], "Post 1"},
+		"Second post":      {payloads[1].Entry()["Title"], "Post 2"},
+		"Event":            {payloads[1].Event(), Update.String()},
+		"Single":           {payloads[2].Metadata()["model"], "homepage"},
+		"Localized single": {payloads[3].Metadata()["locale"], "pl"},
+	})
+}
+
+func TestClient_V5(t *testing.T) {
+	var requested *http.Request
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r
+		status := r.URL.Query().Get("status")
+
+		switch r.URL.Path {
+		case "/api/posts/abc":
+			_, _ = fmt.Fprintf(w, `{"data": {"id": 2, "documentId": "abc", "Title": "%s", "author": {"id": 3, "documentId": "def"}}, "meta": {}}`, status)
+		case "/api/posts":
+			if status == "published" {
+				_, _ = fmt.Fprint(w, `{"data": [{"id": 2, "documentId": "abc", "locale": "en"}], "meta": {"pagination": {"page": 1, "pageCount": 1}}}`)
+				return
+			}
+
+			_, _ = fmt.Fprint(w, `{"data": [{"id": 1, "documentId": "abc", "locale": "en"}, {"id": 4, "documentId": "ghi", "locale": "en"}], "meta": {"pagination": {"page": 1, "pageCount": 1}}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(midas.StrapiSettings{Url: server.URL, Version: V5})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("PopulatePayload", func(t *testing.T) {
+		payload, err := ParsePayload([]byte(`{"event": "entry.publish", "model": "post", "entry": {"id": 2, "documentId": "abc", "publishedAt": "2024-01-01T10:10:10.000Z"}}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err = client.PopulatePayload(payload, midas.ModelSettings{}, false); err != nil {
+			t.Fatal(err)
+		}
+
+		author, _ := payload.Entry()["author"].(map[string]interface{})
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Path":   {requested.URL.Path, "/api/posts/abc"},
+			"Status": {requested.URL.Query().Get("status"), "published"},
+			"State":  {requested.URL.Query().Get("publicationState"), ""},
+			"Title":  {payload.Entry()["Title"], "published"},
+			"Author": {author["documentId"], "def"},
+		})
+	})
+
+	t.Run("Localized", func(t *testing.T) {
+		payload, err := ParsePayload([]byte(`{"event": "entry.publish", "model": "post", "entry": {"id": 5, "documentId": "abc", "locale": "pl", "publishedAt": "2024-01-01T10:10:10.000Z"}}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err = client.PopulatePayload(payload, midas.ModelSettings{}, false); err != nil {
+			t.Fatal(err)
+		}
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Path":   {requested.URL.Path, "/api/posts/abc"},
+			"Locale": {requested.URL.Query().Get("locale"), "pl"},
+		})
+
+		if _, err = client.FetchPayload(midas.Dependency{Model: "post", Id: "abc", Locale: "pl"}, midas.ModelSettings{}, false); err != nil {
+			t.Fatal(err)
+		}
+
+		testing_utils.AssertEquals(t, requested.URL.Query().Get("locale"), "pl", "Dependency locale")
+	})
+
+	t.Run("FetchAll", func(t *testing.T) {
+		payloads, err := client.FetchAll(midas.Site{
+			Locales:         midas.LocaleSettings{Default: "en"},
+			CollectionTypes: map[string]midas.ModelSettings{"post": {}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testing_utils.AssertEquals(t, len(payloads), 2, "Payloads")
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Locale":    {requested.URL.Query().Get("locale"), "*"},
+			"Published": {payloads[0].Metadata()["entryId"], "abc"},
+			"Draft":     {payloads[1].Metadata()["entryId"], "ghi"},
+			"Version":   {payloads[1].Metadata()["version"], V5},
+		})
+	})
+
+	t.Run("DocumentIds", func(t *testing.T) {
+		ids, err := client.DocumentIds("post", midas.ModelSettings{}, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		testing_utils.AssertEquals(t, len(ids), 3, "Document ids")
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Published": {ids["post-2"], "post-abc"},
+			"Draft":     {ids["post-1"], "post-abc"},
+			"Other":     {ids["post-4"], "post-ghi"},
+			"Fields":    {requested.URL.Query().Get("fields[0]"), "documentId"},
+		})
+	})
+
+	t.Run("UnsupportedVersion", func(t *testing.T) {
+		_, err := NewClient(midas.StrapiSettings{Url: server.URL, Version: 3})
+		testing_utils.AssertEquals(t, midas.ErrorCode(err), midas.ErrSiteConfig, "Error code")
+	})
+}
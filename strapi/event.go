@@ -20,38 +20,60 @@ const (
 	Delete
 	Publish
 	Unpublish
+	// DraftDiscard is sent by Strapi v5 when the draft of a published document is discarded.
+	DraftDiscard
+	MediaCreate
+	MediaUpdate
+	MediaDelete
 )
 
 var toJson = map[Event]string{
-	Undefined: "",
-	Create:    "entry.create",
-	Update:    "entry.update",
-	Delete:    "entry.delete",
-	Publish:   "entry.publish",
-	Unpublish: "entry.unpublish",
+	Undefined:    "",
+	Create:       "entry.create",
+	Update:       "entry.update",
+	Delete:       "entry.delete",
+	Publish:      "entry.publish",
+	Unpublish:    "entry.unpublish",
+	DraftDiscard: "entry.draft-discard",
+	MediaCreate:  "media.create",
+	MediaUpdate:  "media.update",
+	MediaDelete:  "media.delete",
 }
 
 var toString = map[Event]string{
-	Undefined: "",
-	Create:    "Create",
-	Update:    "Update",
-	Delete:    "Delete",
-	Publish:   "Publish",
-	Unpublish: "Unpublish",
+	Undefined:    "",
+	Create:       "Create",
+	Update:       "Update",
+	Delete:       "Delete",
+	Publish:      "Publish",
+	Unpublish:    "Unpublish",
+	DraftDiscard: "DraftDiscard",
+	MediaCreate:  "MediaCreate",
+	MediaUpdate:  "MediaUpdate",
+	MediaDelete:  "MediaDelete",
 }
 
 var toId = map[string]Event{
-	"entry.create":    Create,
-	"entry.update":    Update,
-	"entry.delete":    Delete,
-	"entry.publish":   Publish,
-	"entry.unpublish": Unpublish,
+	"entry.create":        Create,
+	"entry.update":        Update,
+	"entry.delete":        Delete,
+	"entry.publish":       Publish,
+	"entry.unpublish":     Unpublish,
+	"entry.draft-discard": DraftDiscard,
+	"media.create":        MediaCreate,
+	"media.update":        MediaUpdate,
+	"media.delete":        MediaDelete,
 }
 
 func (event Event) String() string {
 	return toString[event]
 }
 
+// IsMedia tells if the event concerns the media library instead of the entries.
+func (event Event) IsMedia() bool {
+	return event == MediaCreate || event == MediaUpdate || event == MediaDelete
+}
+
 func (event Event) MarshalJSON() ([]byte, error) {
 	buffer := bytes.NewBufferString(`"`)
 	buffer.WriteString(toJson[event])
@@ -0,0 +1,250 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package strapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/kovansky/midas"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+var _ midas.MediaPayload = (*MediaPayload)(nil)
+
+// MediaModel is the model in the metadata of the media payloads.
+const MediaModel = "media"
+
+// mediaClient is used to download the media into the mirror directory.
+var mediaClient = &http.Client{Timeout: 5 * time.Minute}
+
+// MediaPayload is the payload of the media library events. The uploaded file is the entry of the payload.
+type MediaPayload struct {
+	event     Event
+	CreatedAt time.Time
+	metadata  map[string]interface{}
+	media     map[string]interface{}
+}
+
+// ParseMediaPayload parses the webhook payload of the media library event.
+func ParseMediaPayload(json []byte) (*MediaPayload, error) {
+	payload := MediaPayload{}
+	if err := payload.UnmarshalJSON(json); err != nil {
+		return nil, err
+	}
+
+	if !payload.event.IsMedia() {
+		return nil, midas.Errorf(midas.ErrInvalid, "event %s is not a media event", payload.event)
+	}
+
+	payload.createMetadataMap()
+
+	return &payload, nil
+}
+
+func (p MediaPayload) Event() string {
+	return p.event.String()
+}
+
+func (p MediaPayload) Metadata() map[string]interface{} {
+	return p.metadata
+}
+
+func (p *MediaPayload) createMetadataMap() {
+	p.metadata = map[string]interface{}{
+		"event":     p.event,
+		"createdAt": p.CreatedAt,
+		"model":     MediaModel,
+		"entryId":   p.media["id"],
+		"name":      p.media["name"],
+		"mime":      p.media["mime"],
+		"url":       p.media["url"],
+	}
+}
+
+func (p MediaPayload) Entry() map[string]interface{} {
+	return p.media
+}
+
+func (p *MediaPayload) SetEntry(media map[string]interface{}) {
+	p.media = media
+	p.createMetadataMap()
+}
+
+func (p MediaPayload) Raw() interface{} {
+	return p
+}
+
+// Urls returns the URLs of the uploaded file and its formats (i.e. thumbnails), sorted.
+func (p MediaPayload) Urls() []string {
+	var urls []string
+	if mediaUrl, ok := p.media["url"].(string); ok && mediaUrl != "" {
+		urls = append(urls, mediaUrl)
+	}
+
+	formats, _ := p.media["formats"].(map[string]interface{})
+	for _, format := range formats {
+		format, _ := format.(map[string]interface{})
+		if formatUrl, ok := format["url"].(string); ok && formatUrl != "" {
+			urls = append(urls, formatUrl)
+		}
+	}
+
+	sort.Strings(urls)
+
+	return urls
+}
+
+func (p MediaPayload) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Event     Event                  `json:"event"`
+		CreatedAt time.Time              `json:"createdAt"`
+		Media     map[string]interface{} `json:"media,omitempty"`
+	}{
+		p.event, p.CreatedAt, p.media,
+	})
+}
+
+func (p *MediaPayload) UnmarshalJSON(bytes []byte) error {
+	var data struct {
+		Event     Event                  `json:"event"`
+		CreatedAt time.Time              `json:"createdAt"`
+		Media     map[string]interface{} `json:"media,omitempty"`
+	}
+	if err := json.Unmarshal(bytes, &data); err != nil {
+		return err
+	}
+
+	*p = MediaPayload{
+		event:     data.Event,
+		CreatedAt: data.CreatedAt,
+		media:     data.Media,
+	}
+	return nil
+}
+
+// Mirror mirrors the media library change into the media directory of the site: the uploaded file and its formats are
+// downloaded (replacing the existing files), or removed on delete. Files keep the names from the media URLs, so the
+// site can serve them under the same paths as the CMS. Each file is passed to stage before it's changed. Returns the
+// paths of the changed files.
+func (p MediaPayload) Mirror(site midas.Site, stage func(path string) error) ([]string, error) {
+	if site.MediaDir == "" {
+		return nil, midas.Errorf(midas.ErrSiteConfig, "mediaDir is not set")
+	}
+
+	mediaDir := filepath.Clean(site.MediaDir)
+	if !filepath.IsAbs(mediaDir) {
+		mediaDir = filepath.Join(site.RootDir, mediaDir)
+	}
+
+	if err := os.MkdirAll(mediaDir, 0775); err != nil {
+		return nil, err
+	}
+
+	var baseUrl *url.URL
+	if site.MediaUrl != "" {
+		parsed, err := url.Parse(site.MediaUrl)
+		if err != nil {
+			return nil, midas.Errorf(midas.ErrSiteConfig, "mediaUrl %s is invalid: %s", site.MediaUrl, err)
+		}
+
+		baseUrl = parsed
+	}
+
+	var changed []string
+	for _, mediaUrl := range p.Urls() {
+		parsed, err := url.Parse(mediaUrl)
+		if err != nil {
+			return changed, midas.Errorf(midas.ErrInvalid, "media URL %s is invalid: %s", mediaUrl, err)
+		}
+
+		fileName := path.Base(parsed.Path)
+		if fileName == "." || fileName == "/" || fileName == ".." {
+			return changed, midas.Errorf(midas.ErrInvalid, "media URL %s has no file name", mediaUrl)
+		}
+
+		filePath := filepath.Join(mediaDir, fileName)
+
+		if err = stage(filePath); err != nil {
+			return changed, err
+		}
+
+		if p.event == MediaDelete {
+			if err = os.Remove(filePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return changed, err
+			} else if err == nil {
+				changed = append(changed, filePath)
+			}
+
+			continue
+		}
+
+		if !parsed.IsAbs() {
+			if baseUrl == nil {
+				return changed, midas.Errorf(midas.ErrSiteConfig, "mediaUrl is required to download media %s", mediaUrl)
+			}
+
+			parsed = baseUrl.ResolveReference(parsed)
+		}
+
+		if err = downloadMedia(parsed.String(), filePath); err != nil {
+			return changed, err
+		}
+
+		changed = append(changed, filePath)
+	}
+
+	return changed, nil
+}
+
+// downloadMedia downloads the media into the file. The media is written to a temporary file first, so the existing
+// file is kept on error.
+func downloadMedia(mediaUrl, filePath string) error {
+	resp, err := mediaClient.Get(mediaUrl)
+	if err != nil {
+		return fmt.Errorf("could not download media %s: %v", mediaUrl, err)
+	}
+	defer func(body io.ReadCloser) {
+		_ = body.Close()
+	}(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("could not download media %s: %s", mediaUrl, resp.Status)
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(filePath), ".midas-media-")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(file.Name())
+	}()
+
+	if _, err = io.Copy(file, resp.Body); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("could not download media %s: %v", mediaUrl, err)
+	}
+
+	// Temporary files are readable by the owner only.
+	if err = file.Chmod(0664); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	if err = file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), filePath)
+}
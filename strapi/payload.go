@@ -8,21 +8,36 @@ package strapi
 
 import (
 	"encoding/json"
+	"fmt"
 	"github.com/kovansky/midas"
 	"time"
 )
 
 var _ midas.Payload = (*Payload)(nil)
 
+const (
+	V4 = 4
+	V5 = 5
+)
+
 type Payload struct {
 	event     Event
 	CreatedAt time.Time
 	Model     string
-	metadata  map[string]interface{}
-	entry     map[string]interface{}
+	// Version is the major version of Strapi which sent the payload.
+	Version  int
+	metadata map[string]interface{}
+	entry    map[string]interface{}
 }
 
+// ParsePayload parses the webhook payload, detecting the version of Strapi.
 func ParsePayload(json []byte) (midas.Payload, error) {
+	return ParsePayloadVersion(json, 0)
+}
+
+// ParsePayloadVersion parses the webhook payload sent by the given version of Strapi. With version 0, the version is
+// detected from the payload: v5 entries have a documentId.
+func ParsePayloadVersion(json []byte, version int) (midas.Payload, error) {
 	payload := Payload{}
 	err := payload.UnmarshalJSON(json)
 
@@ -30,11 +45,33 @@ func ParsePayload(json []byte) (midas.Payload, error) {
 		return nil, err
 	}
 
+	if payload.event.IsMedia() {
+		return ParseMediaPayload(json)
+	}
+
+	switch version {
+	case 0:
+		payload.Version = detectVersion(payload.entry)
+	case V4, V5:
+		payload.Version = version
+	default:
+		return nil, midas.Errorf(midas.ErrSiteConfig, "strapi version %d is not supported", version)
+	}
+
 	payload.createMetadataMap()
 
 	return &payload, nil
 }
 
+// detectVersion detects the version of Strapi from the entry.
+func detectVersion(entry map[string]interface{}) int {
+	if _, ok := entry["documentId"]; ok {
+		return V5
+	}
+
+	return V4
+}
+
 func (p Payload) Event() string {
 	return p.event.String()
 }
@@ -49,20 +86,117 @@ func (p *Payload) createMetadataMap() {
 	asMap["event"] = p.event
 	asMap["createdAt"] = p.CreatedAt
 	asMap["model"] = p.Model
+	asMap["version"] = p.Version
+	// Identifies the entry across the events: v5 entry ids differ between the draft and published versions of the
+	// document, so the document id is used.
+	asMap["entryId"] = p.entryId()
 
 	asMap["published"] = false
 	if val, ok := p.entry["publishedAt"]; ok {
 		asMap["published"] = val != nil
+
+		// With Draft & Publish enabled, unpublished entries are drafts.
+		asMap["draft"] = val == nil
+	}
+
+	// With i18n enabled, translations of the entry share the translation key, based on the lowest id among them (or the
+	// default language translation, see SetLocales).
+	if locale, ok := p.entry["locale"].(string); ok {
+		asMap["locale"] = locale
+		asMap["translationKey"] = fmt.Sprintf("%s-%v", p.Model, p.translationId())
+		if p.Version == V5 {
+			// Translations share the document.
+			asMap["translationKey"] = fmt.Sprintf("%s-%v", p.Model, p.entryId())
+		}
+	}
+
+	switch p.event {
+	case Publish:
+		asMap["published"], asMap["draft"] = true, false
+	case Unpublish:
+		asMap["published"], asMap["draft"] = false, true
 	}
 
 	p.metadata = asMap
 }
 
+// entryId returns the id of the entry, stable across the events: document id for v5, entry id otherwise.
+func (p *Payload) entryId() interface{} {
+	if documentId, ok := p.entry["documentId"]; ok && p.Version == V5 {
+		return documentId
+	}
+
+	return p.entry["id"]
+}
+
+// DraftOnly tells if the payload only changes the draft of a Strapi v5 document, so the published content is not
+// affected. Strapi v5 keeps the draft and published versions of the document separately, and sends create and
+// update events for the draft version.
+func DraftOnly(payload midas.Payload) bool {
+	if payload.Metadata()["version"] != V5 {
+		return false
+	}
+
+	switch payload.Event() {
+	case DraftDiscard.String():
+		return true
+	case Create.String(), Update.String():
+		publishedAt, ok := payload.Entry()["publishedAt"]
+		// Without draft & publish, entries have no drafts.
+		return ok && publishedAt == nil
+	default:
+		return false
+	}
+}
+
+// SetLocales bases the translation key of the Strapi v4 entry on the id of its translation in the default language of
+// the site, if the entry is that translation or lists it in the localizations. Unlike the lowest id among the
+// translations, it doesn't change when other translations are deleted. Strapi v5 translations share the document id,
+// which is used instead.
+func (p *Payload) SetLocales(locales midas.LocaleSettings) {
+	if _, ok := p.metadata["translationKey"]; !ok || p.Version == V5 || locales.Default == "" {
+		return
+	}
+
+	localizations, _ := p.entry["localizations"].([]interface{})
+	for _, translation := range append([]interface{}{p.entry}, localizations...) {
+		translation, ok := translation.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if locale, ok := translation["locale"].(string); ok && locales.Language(locale) == locales.Default {
+			p.metadata["translationKey"] = fmt.Sprintf("%s-%v", p.Model, translation["id"])
+			return
+		}
+	}
+}
+
+// translationId returns the lowest id among the entry and its localizations.
+func (p *Payload) translationId() interface{} {
+	id := p.entry["id"]
+
+	localizations, _ := p.entry["localizations"].([]interface{})
+	for _, localization := range localizations {
+		localization, ok := localization.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		otherId, isNumber := localization["id"].(float64)
+		if currentId, ok := id.(float64); isNumber && ok && otherId < currentId {
+			id = otherId
+		}
+	}
+
+	return id
+}
+
 func (p Payload) Entry() map[string]interface{} {
 	return p.entry
 }
 
-func (p Payload) SetEntry(entry map[string]interface{}) {
+func (p *Payload) SetEntry(entry map[string]interface{}) {
 	p.entry = entry
 }
 
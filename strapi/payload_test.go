@@ -0,0 +1,121 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package strapi
+
+import (
+	"fmt"
+	"github.com/kovansky/midas"
+	"github.com/kovansky/midas/testing_utils"
+	"testing"
+)
+
+const v5Entry = `{"id": 7, "documentId": "abc123", "locale": "en", "Title": "Hello", "publishedAt": null, "localizations": [{"id": 8, "documentId": "abc123", "locale": "pl"}]}`
+
+func parseEvent(t *testing.T, event, entry string, version int) midas.Payload {
+	payload, err := ParsePayloadVersion([]byte(fmt.Sprintf(`{
+    "event": "%s",
+    "createdAt": "2024-01-01T10:10:10.000Z",
+    "model": "post",
+    "entry": %s
+  }`, event, entry)), version)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return payload
+}
+
+func TestParsePayloadVersion(t *testing.T) {
+	detected := parseEvent(t, "entry.update", v5Entry, 0)
+	v4 := parseEvent(t, "entry.update", `{"id": 7, "locale": "en", "localizations": [{"id": 3, "locale": "pl"}]}`, 0)
+	forced := parseEvent(t, "entry.update", `{"id": 7}`, V5)
+
+	testing_utils.AssertTable(t, map[string][]interface{}{
+		"Detected v5":        {detected.Metadata()["version"], V5},
+		"v5 entry id":        {detected.Metadata()["entryId"], "abc123"},
+		"v5 translation key": {detected.Metadata()["translationKey"], "post-abc123"},
+		"Detected v4":        {v4.Metadata()["version"], V4},
+		"v4 entry id":        {v4.Metadata()["entryId"], float64(7)},
+		"v4 translation key": {v4.Metadata()["translationKey"], "post-3"},
+		"Forced v5":          {forced.Metadata()["version"], V5},
+	})
+
+	_, err := ParsePayloadVersion([]byte(`{"event": "entry.update", "model": "post", "entry": {"id": 1}}`), 3)
+	testing_utils.AssertEquals(t, midas.ErrorCode(err), midas.ErrSiteConfig, "Unsupported version")
+}
+
+func TestPayload_SetLocales(t *testing.T) {
+	locales := midas.LocaleSettings{Default: "en", Mapping: map[string]string{"en-US": "en"}}
+
+	// The translation with the lowest id was deleted, the key stays the id of the default language translation.
+	translation := parseEvent(t, "entry.update", `{"id": 9, "locale": "de", "localizations": [{"id": 7, "locale": "en-US"}, {"id": 8, "locale": "pl"}]}`, V4)
+	translation.(*Payload).SetLocales(locales)
+
+	original := parseEvent(t, "entry.update", `{"id": 7, "locale": "en-US", "localizations": [{"id": 9, "locale": "de"}]}`, V4)
+	original.(*Payload).SetLocales(locales)
+
+	unlisted := parseEvent(t, "entry.update", `{"id": 9, "locale": "de", "localizations": [{"id": 8, "locale": "pl"}]}`, V4)
+	unlisted.(*Payload).SetLocales(locales)
+
+	testing_utils.AssertTable(t, map[string][]interface{}{
+		"Translation":      {translation.Metadata()["translationKey"], "post-7"},
+		"Default language": {original.Metadata()["translationKey"], "post-7"},
+		"Lowest id":        {unlisted.Metadata()["translationKey"], "post-8"},
+	})
+}
+
+func TestDraftOnly(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload midas.Payload
+		want    bool
+	}{
+		{"v5 draft update", parseEvent(t, "entry.update", v5Entry, 0), true},
+		{"v5 draft create", parseEvent(t, "entry.create", v5Entry, 0), true},
+		{"v5 publish", parseEvent(t, "entry.publish", v5Entry, 0), false},
+		{"v5 published update", parseEvent(t, "entry.update", `{"id": 9, "documentId": "abc123", "publishedAt": "2024-01-01T10:10:10.000Z"}`, 0), false},
+		{"v5 without draft & publish", parseEvent(t, "entry.update", `{"id": 9, "documentId": "abc123"}`, 0), false},
+		{"v5 draft discard", parseEvent(t, "entry.draft-discard", v5Entry, 0), true},
+		{"v4 draft update", parseEvent(t, "entry.update", `{"id": 7, "publishedAt": null}`, 0), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testing_utils.AssertEquals(t, DraftOnly(tt.payload), tt.want, "DraftOnly")
+		})
+	}
+}
+
+func TestParsePayload_Media(t *testing.T) {
+	payload, err := ParsePayload([]byte(`{
+    "event": "media.update",
+    "createdAt": "2024-01-01T10:10:10.000Z",
+    "media": {
+      "id": 4,
+      "url": "/uploads/photo.png",
+      "formats": {"small": {"url": "/uploads/small_photo.png"}, "thumbnail": {"url": "/uploads/thumbnail_photo.png"}}
+    }
+  }`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	media, ok := payload.(*MediaPayload)
+	if !ok {
+		t.Fatalf("ParsePayload() = %T, want *MediaPayload", payload)
+	}
+
+	urls := media.Urls()
+
+	testing_utils.AssertEquals(t, len(urls), 3, "Urls")
+	testing_utils.AssertTable(t, map[string][]interface{}{
+		"Event":    {media.Event(), MediaUpdate.String()},
+		"Model":    {media.Metadata()["model"], MediaModel},
+		"Entry id": {media.Metadata()["entryId"], float64(4)},
+		"Original": {urls[0], "/uploads/photo.png"},
+		"Format":   {urls[2], "/uploads/thumbnail_photo.png"},
+	})
+}
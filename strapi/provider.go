@@ -0,0 +1,201 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package strapi
+
+import (
+	"fmt"
+	"github.com/kovansky/midas"
+	"github.com/rs/zerolog"
+	"sort"
+)
+
+var _ midas.SyncProvider = (*Provider)(nil)
+
+// Provider maps the Strapi webhooks to the content changes of the sites.
+type Provider struct{}
+
+func NewProvider() *Provider {
+	return &Provider{}
+}
+
+// Changes returns the change of the webhook entry, followed by the updates of the entries referencing it, so they
+// include its changes. Entries of the models which are not generated are accepted only if they're referenced by the
+// generated ones (i.e. authors embedded in posts). With the strapi url of the site, the entry is re-fetched from the
+// REST API, with relations and components populated.
+func (p Provider) Changes(site midas.Site, siteService midas.SiteService, webhook midas.Webhook, log zerolog.Logger) ([]midas.Change, error) {
+	payload, err := ParsePayloadVersion(webhook.Body, site.Strapi.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	if media, ok := payload.(*MediaPayload); ok {
+		return p.media(site, media)
+	}
+
+	model := payload.Metadata()["model"].(string)
+	settings, isSingle := site.SingleTypes[model]
+	collectionSettings, isCollection := site.CollectionTypes[model]
+	if !isSingle {
+		settings = collectionSettings
+	}
+
+	dependents, err := p.dependents(siteService, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isSingle && !isCollection && len(dependents) == 0 {
+		return nil, midas.Errorf(midas.ErrUnaccepted, "model %s is not accepted", model)
+	}
+
+	if DraftOnly(payload) {
+		log.Info().Msg("Only the draft changed, the content is not regenerated")
+		return nil, nil
+	}
+
+	var changes []midas.Change
+
+	if isSingle || isCollection {
+		var action midas.Action
+
+		switch payload.Event() {
+		case Create.String():
+			action = midas.ActionCreate
+		case Update.String(), Publish.String(), Unpublish.String():
+			// Publication state is passed in the payload metadata, so the entry is just updated.
+			action = midas.ActionUpdate
+		case Delete.String():
+			action = midas.ActionDelete
+		default:
+			return nil, midas.Errorf(midas.ErrInvalid, "event %s is invalid", payload.Event())
+		}
+
+		// Deleted entries can't be fetched anymore and their content is only removed, so they're not populated.
+		if site.Strapi.Url != "" && action != midas.ActionDelete {
+			client, err := NewClient(p.settings(site, payload))
+			if err != nil {
+				return nil, err
+			}
+
+			if err = client.PopulatePayload(payload, settings, isSingle); err != nil {
+				return nil, err
+			}
+		}
+
+		if strapiPayload, ok := payload.(*Payload); ok {
+			strapiPayload.SetLocales(site.Locales)
+		}
+
+		changes = append(changes, midas.Change{Action: action, Payload: payload})
+	}
+
+	updates, err := p.updateDependents(site, payload, dependents, log)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(changes, updates...), nil
+}
+
+// FetchAll fetches all entries of the site from the Strapi REST API.
+func (p Provider) FetchAll(site midas.Site) ([]midas.Payload, error) {
+	client, err := NewClient(site.Strapi)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.FetchAll(site)
+}
+
+// media returns the change mirroring the media library change into the media directory of the site, applied by the
+// site service with the content. Media events are not accepted if the media directory is not set.
+func (p Provider) media(site midas.Site, media *MediaPayload) ([]midas.Change, error) {
+	if site.MediaDir == "" {
+		return nil, midas.Errorf(midas.ErrUnaccepted, "media events are not accepted, mediaDir is not set")
+	}
+
+	return []midas.Change{{Action: midas.ActionMedia, Payload: media}}, nil
+}
+
+// dependents returns the dependencies of the entries referencing the payload entry.
+func (p Provider) dependents(siteService midas.SiteService, payload midas.Payload) (midas.Dependencies, error) {
+	registry, err := siteService.GetRegistryService()
+	if err != nil {
+		return nil, err
+	}
+
+	return registry.ReadDependents(fmt.Sprintf("%v-%v", payload.Metadata()["model"], payload.Metadata()["entryId"]))
+}
+
+// updateDependents returns the updates of the entries referencing the payload entry. The entries are fetched from the
+// Strapi REST API, so they can't be regenerated without the strapi settings of the site.
+func (p Provider) updateDependents(site midas.Site, payload midas.Payload, dependents midas.Dependencies, log zerolog.Logger) ([]midas.Change, error) {
+	if len(dependents) == 0 {
+		return nil, nil
+	}
+
+	if site.Strapi.Url == "" {
+		log.Warn().Msgf("%d dependent entries not regenerated, strapi url is not set", len(dependents))
+		return nil, nil
+	}
+
+	client, err := NewClient(p.settings(site, payload))
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(dependents))
+	for id := range dependents {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var changes []midas.Change
+
+	for _, id := range ids {
+		dependency := dependents[id]
+		if dependency.Model == payload.Metadata()["model"] && dependency.Id == fmt.Sprint(payload.Metadata()["entryId"]) {
+			continue
+		}
+
+		settings, isSingle := site.SingleTypes[dependency.Model]
+		if !isSingle {
+			var ok bool
+			if settings, ok = site.CollectionTypes[dependency.Model]; !ok {
+				// The model is not generated anymore.
+				continue
+			}
+		}
+
+		dependent, err := client.FetchPayload(dependency, settings, isSingle)
+		if midas.ErrorCode(err) == midas.ErrNotFound {
+			// The entry was removed in the meantime, its own webhook handles it.
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		dependent.(*Payload).SetLocales(site.Locales)
+
+		log.Info().Msgf("Regenerating dependent entry %s", id)
+
+		changes = append(changes, midas.Change{Action: midas.ActionUpdate, Payload: dependent})
+	}
+
+	return changes, nil
+}
+
+// settings returns the Strapi settings of the site, with the version of Strapi which sent the payload if it's not
+// configured.
+func (p Provider) settings(site midas.Site, payload midas.Payload) midas.StrapiSettings {
+	settings := site.Strapi
+	if settings.Version == 0 {
+		settings.Version, _ = payload.Metadata()["version"].(int)
+	}
+
+	return settings
+}
@@ -0,0 +1,51 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package walk
+
+import (
+	"os"
+	"time"
+)
+
+var _ os.FileInfo = (*RemoteFileInfo)(nil)
+
+// RemoteFileInfo is an os.FileInfo implementation for files listed on remote servers, which protocols don't provide
+// one on their own (i.e. WebDAV).
+type RemoteFileInfo struct {
+	FileName    string
+	FileSize    int64
+	FileModTime time.Time
+	Dir         bool
+}
+
+func (f RemoteFileInfo) Name() string {
+	return f.FileName
+}
+
+func (f RemoteFileInfo) Size() int64 {
+	return f.FileSize
+}
+
+func (f RemoteFileInfo) Mode() os.FileMode {
+	if f.Dir {
+		return os.ModeDir | 0755
+	}
+
+	return 0644
+}
+
+func (f RemoteFileInfo) ModTime() time.Time {
+	return f.FileModTime
+}
+
+func (f RemoteFileInfo) IsDir() bool {
+	return f.Dir
+}
+
+func (f RemoteFileInfo) Sys() interface{} {
+	return nil
+}
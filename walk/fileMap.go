@@ -6,7 +6,10 @@
 
 package walk
 
-import "os"
+import (
+	"os"
+	"time"
+)
 
 // FileOperationType is used to identify what kind of operation should be performed on a file.
 type FileOperationType int64
@@ -42,7 +45,8 @@ func (f FileMap) Diff(other FileMap) (diff []FileOperation) {
 				Type: UploadFile,
 			})
 		} else {
-			if info.ModTime().After(otherInfo.ModTime()) {
+			// Remote servers report modification times with a precision of seconds.
+			if info.ModTime().Truncate(time.Second).After(otherInfo.ModTime()) {
 				diff = append(diff, FileOperation{
 					Path: name,
 					Info: info,
@@ -0,0 +1,40 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package walk
+
+import (
+	"github.com/kovansky/midas"
+	"sort"
+)
+
+// Plan converts the file operations into a deployment plan. remotePath maps the relative path of a file to its path on
+// the deployment target.
+func Plan(operations []FileOperation, remotePath func(filePath string) string) *midas.DeploymentPlan {
+	plan := &midas.DeploymentPlan{
+		Upload: []string{},
+		Update: []string{},
+		Delete: []string{},
+	}
+
+	for _, operation := range operations {
+		switch operation.Type {
+		case UploadFile:
+			plan.Upload = append(plan.Upload, remotePath(operation.Path))
+		case UpdateFile:
+			plan.Update = append(plan.Update, remotePath(operation.Path))
+		case RemoveFile:
+			plan.Delete = append(plan.Delete, remotePath(operation.Path))
+		}
+	}
+
+	// Diff iterates over maps, so the order is random.
+	sort.Strings(plan.Upload)
+	sort.Strings(plan.Update)
+	sort.Strings(plan.Delete)
+
+	return plan
+}
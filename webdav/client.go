@@ -0,0 +1,374 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package webdav
+
+import (
+	"encoding/xml"
+	"fmt"
+	"github.com/kovansky/midas"
+	"github.com/kovansky/midas/walk"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const propfindBody = `<?xml version="1.0" encoding="utf-8"?>
+<d:propfind xmlns:d="DAV:">
+  <d:prop>
+    <d:resourcetype/>
+    <d:getcontentlength/>
+    <d:getlastmodified/>
+  </d:prop>
+</d:propfind>`
+
+type Client struct {
+	settings midas.WebDAVDeploymentSettings
+	// baseUrl is the server URL from configuration. Directories above it are never created by the client.
+	baseUrl *url.URL
+	// rootDir is the remote directory of the website, relative to baseUrl.
+	rootDir string
+
+	httpClient *http.Client
+	digest     *digestChallenge
+	nonceCount int
+	// createdDirs caches directories that are known to exist, so we don't send MKCOL for every uploaded file.
+	createdDirs map[string]struct{}
+}
+
+// NewClient creates a new WebDAV client.
+func NewClient(settings midas.WebDAVDeploymentSettings) (*Client, error) {
+	baseUrl, err := url.Parse(settings.Url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid webdav url %s: %v", settings.Url, err)
+	}
+
+	if baseUrl.Scheme != "http" && baseUrl.Scheme != "https" {
+		return nil, fmt.Errorf("invalid webdav url %s: scheme must be http or https", settings.Url)
+	}
+
+	if settings.Method == "" {
+		if settings.Password != "" {
+			settings.Method = "basic"
+		} else {
+			settings.Method = "none"
+		}
+	}
+
+	switch settings.Method {
+	case "none", "basic", "digest":
+	default:
+		return nil, fmt.Errorf("unsupported webdav authentication method %s", settings.Method)
+	}
+
+	return &Client{
+		settings:    settings,
+		baseUrl:     baseUrl,
+		rootDir:     strings.Trim(path.Clean("/"+settings.Path), "/"),
+		httpClient:  &http.Client{Timeout: 5 * time.Minute},
+		createdDirs: make(map[string]struct{}),
+	}, nil
+}
+
+// RemoteFiles returns a list of files in the remote directory.
+//
+// The tree is listed with subsequent PROPFIND requests with depth 1, as many servers (i.e. Nextcloud) have infinite
+// depth disabled.
+func (c *Client) RemoteFiles() (walk.FileMap, []error) {
+	var (
+		errors []error
+		files  = make(walk.FileMap)
+		queue  = []string{""}
+	)
+
+	for len(queue) > 0 {
+		dir := queue[0]
+		queue = queue[1:]
+
+		entries, err := c.propfind(dir)
+		if err != nil {
+			errors = append(errors, err)
+			continue
+		}
+
+		for relPath, info := range entries {
+			if info.IsDir() {
+				queue = append(queue, relPath)
+			} else {
+				files[relPath] = info
+			}
+		}
+	}
+
+	return files, errors
+}
+
+// UploadNewFile creates (or overwrites) a file in the remote server.
+func (c *Client) UploadNewFile(filePath string, file io.ReadSeeker) error {
+	if err := c.MkdirAll(path.Dir(filePath)); err != nil {
+		return err
+	}
+
+	resp, err := c.do(http.MethodPut, c.remotePath(filePath), nil, file)
+	if err != nil {
+		return fmt.Errorf("failed to upload file %s to remote: %s", filePath, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to upload file %s to remote: %s", filePath, resp.Status)
+	}
+
+	return nil
+}
+
+// RemoveFile removes a file from the remote server.
+func (c *Client) RemoveFile(filePath string) error {
+	resp, err := c.do(http.MethodDelete, c.remotePath(filePath), nil, nil)
+	if err != nil {
+		return fmt.Errorf("could not remove file %s from remote: %s", filePath, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("could not remove file %s from remote: %s", filePath, resp.Status)
+	}
+
+	return nil
+}
+
+// RemoveDir removes a directory with all its contents from the remote server.
+func (c *Client) RemoveDir(dir string) error {
+	remoteDir := c.remotePath(dir)
+
+	resp, err := c.do(http.MethodDelete, remoteDir+"/", nil, nil)
+	if err != nil {
+		return fmt.Errorf("could not remove directory %s from remote: %s", dir, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("could not remove directory %s from remote: %s", dir, resp.Status)
+	}
+
+	for created := range c.createdDirs {
+		if created == remoteDir || strings.HasPrefix(created, remoteDir+"/") {
+			delete(c.createdDirs, created)
+		}
+	}
+
+	return nil
+}
+
+// MkdirAll creates the directory (relative to the website root) with all its parents using MKCOL requests.
+func (c *Client) MkdirAll(dir string) error {
+	current := ""
+	segments := strings.Split(path.Join(c.rootDir, dir), "/")
+
+	for _, segment := range segments {
+		if segment == "" || segment == "." {
+			continue
+		}
+
+		current = path.Join(current, segment)
+		if _, ok := c.createdDirs[current]; ok {
+			continue
+		}
+
+		resp, err := c.do("MKCOL", current+"/", nil, nil)
+		if err != nil {
+			return fmt.Errorf("could not create directory %s in remote: %s", current, err)
+		}
+		_ = resp.Body.Close()
+
+		// 405 Method Not Allowed is returned when the collection already exists.
+		if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusMethodNotAllowed {
+			return fmt.Errorf("could not create directory %s in remote: %s", current, resp.Status)
+		}
+
+		c.createdDirs[current] = struct{}{}
+	}
+
+	return nil
+}
+
+// propfind lists the direct children of the directory (relative to the website root).
+func (c *Client) propfind(dir string) (walk.FileMap, error) {
+	header := http.Header{}
+	header.Set("Depth", "1")
+	header.Set("Content-Type", "application/xml; charset=utf-8")
+
+	requested := c.remotePath(dir)
+
+	resp, err := c.do("PROPFIND", requested+"/", header, strings.NewReader(propfindBody))
+	if err != nil {
+		return nil, fmt.Errorf("could not list remote directory %s: %s", dir, err)
+	}
+	defer resp.Body.Close()
+
+	// Website directory was not created yet, so there are no files.
+	if resp.StatusCode == http.StatusNotFound {
+		return walk.FileMap{}, nil
+	}
+	if resp.StatusCode != http.StatusMultiStatus {
+		return nil, fmt.Errorf("could not list remote directory %s: %s", dir, resp.Status)
+	}
+
+	var result multistatus
+	if err = xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("could not parse listing of remote directory %s: %v", dir, err)
+	}
+
+	var (
+		files         = make(walk.FileMap)
+		rootPath      = strings.Trim(path.Join(c.baseUrl.Path, c.rootDir), "/")
+		requestedPath = strings.Trim(path.Join(c.baseUrl.Path, requested), "/")
+	)
+
+	for _, response := range result.Responses {
+		href, err := url.Parse(response.Href)
+		if err != nil {
+			return nil, fmt.Errorf("invalid href %s in listing of remote directory %s", response.Href, dir)
+		}
+
+		// Hrefs may be absolute URLs or absolute paths, we are interested in path relative to the website root.
+		hrefPath := strings.Trim(href.Path, "/")
+		if hrefPath == requestedPath {
+			continue
+		}
+
+		relPath := hrefPath
+		if rootPath != "" {
+			if !strings.HasPrefix(hrefPath, rootPath+"/") {
+				continue
+			}
+
+			relPath = strings.TrimPrefix(hrefPath, rootPath+"/")
+		}
+
+		for _, propstat := range response.Propstat {
+			if !strings.Contains(propstat.Status, " 200 ") {
+				continue
+			}
+
+			info := walk.RemoteFileInfo{
+				FileName: path.Base(relPath),
+				Dir:      propstat.Prop.ResourceType.Collection != nil,
+			}
+			info.FileSize, _ = strconv.ParseInt(propstat.Prop.ContentLength, 10, 64)
+			info.FileModTime, _ = http.ParseTime(propstat.Prop.LastModified)
+
+			files[relPath] = info
+		}
+	}
+
+	return files, nil
+}
+
+// do sends the request to the path (relative to base url), authenticating it according to the configured method.
+//
+// In case of digest authentication, the request is repeated once after receiving the challenge, so the body has to be
+// seekable.
+func (c *Client) do(method, remotePath string, header http.Header, body io.ReadSeeker) (*http.Response, error) {
+	resp, err := c.send(method, remotePath, header, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusUnauthorized || c.settings.Method != "digest" {
+		return resp, nil
+	}
+
+	// Retry with a fresh challenge.
+	_ = resp.Body.Close()
+
+	challenge, err := parseDigestChallenge(resp.Header.Get("WWW-Authenticate"))
+	if err != nil {
+		return nil, err
+	}
+	c.digest = challenge
+	c.nonceCount = 0
+
+	if body != nil {
+		if _, err = body.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+	}
+
+	return c.send(method, remotePath, header, body)
+}
+
+// send builds and sends a single request.
+func (c *Client) send(method, remotePath string, header http.Header, body io.ReadSeeker) (*http.Response, error) {
+	target := *c.baseUrl
+	target.Path = path.Join("/", c.baseUrl.Path, remotePath)
+	target.RawPath = ""
+	if strings.HasSuffix(remotePath, "/") && !strings.HasSuffix(target.Path, "/") {
+		target.Path += "/"
+	}
+
+	var reqBody io.Reader = http.NoBody
+	if body != nil {
+		reqBody = body
+	}
+
+	req, err := http.NewRequest(method, target.String(), reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	switch c.settings.Method {
+	case "basic":
+		req.SetBasicAuth(c.settings.User, c.settings.Password)
+	case "digest":
+		if c.digest != nil {
+			c.nonceCount++
+
+			authorization, err := c.digest.authorization(c.settings.User, c.settings.Password, method, target.RequestURI(), c.nonceCount)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Authorization", authorization)
+		}
+	}
+
+	return c.httpClient.Do(req)
+}
+
+// remotePath returns the path relative to base url of a file (relative to website root).
+func (c *Client) remotePath(filePath string) string {
+	return strings.Trim(path.Join(c.rootDir, filePath), "/")
+}
+
+// urlPath returns the absolute URL path of a file (relative to website root).
+func (c *Client) urlPath(filePath string) string {
+	return path.Join("/", c.baseUrl.Path, c.remotePath(filePath))
+}
+
+type multistatus struct {
+	Responses []struct {
+		Href     string `xml:"DAV: href"`
+		Propstat []struct {
+			Status string `xml:"DAV: status"`
+			Prop   struct {
+				ResourceType struct {
+					Collection *struct{} `xml:"DAV: collection"`
+				} `xml:"DAV: resourcetype"`
+				ContentLength string `xml:"DAV: getcontentlength"`
+				LastModified  string `xml:"DAV: getlastmodified"`
+			} `xml:"DAV: prop"`
+		} `xml:"DAV: propstat"`
+	} `xml:"DAV: response"`
+}
@@ -0,0 +1,215 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package webdav
+
+import (
+	"fmt"
+	"github.com/kovansky/midas"
+	"github.com/kovansky/midas/walk"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+var _ midas.Deployment = (*Deployment)(nil)
+
+type Deployment struct {
+	site               midas.Site
+	deploymentSettings midas.DeploymentSettings
+	publicPath         string
+
+	client *Client
+}
+
+func New(site midas.Site, deploymentSettings midas.DeploymentSettings, isDraft bool) (midas.Deployment, error) {
+	// Get build destination directory
+	var publicPath = site.PublicPath(isDraft)
+
+	client, err := NewClient(deploymentSettings.WebDAV)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Deployment{
+		site:               site,
+		deploymentSettings: deploymentSettings,
+		publicPath:         filepath.ToSlash(publicPath),
+
+		client: client,
+	}, nil
+}
+
+// Deploy uploads the built files to the remote WebDAV server.
+func (d *Deployment) Deploy() error {
+	// Retrieve local files.
+	walker, err := d.retrieveFiles()
+	if err != nil {
+		return err
+	}
+
+	// And get local files as file map
+	fileMap, err := d.getFileMap(walker)
+	if err != nil {
+		return err
+	}
+
+	// Get remote files.
+	remoteFiles, err := d.remoteFiles()
+	if err != nil {
+		return err
+	}
+
+	// Generate diffs
+	diff := fileMap.Diff(remoteFiles)
+
+	var removed []string
+	for _, fileOp := range diff {
+		if err := d.syncFile(fileOp); err != nil {
+			return err
+		}
+
+		if fileOp.Type == walk.RemoveFile {
+			removed = append(removed, fileOp.Path)
+		}
+	}
+
+	return d.removeEmptyDirs(removed, fileMap)
+}
+
+// Plan returns the changes Deploy would make on the remote WebDAV server, without changing anything.
+func (d *Deployment) Plan() (*midas.DeploymentPlan, error) {
+	// Retrieve local files.
+	walker, err := d.retrieveFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	fileMap, err := d.getFileMap(walker)
+	if err != nil {
+		return nil, err
+	}
+
+	remoteFiles, err := d.remoteFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	return walk.Plan(fileMap.Diff(remoteFiles), d.client.urlPath), nil
+}
+
+// syncFile performs a file operation.
+func (d *Deployment) syncFile(operation walk.FileOperation) error {
+	switch operation.Type {
+	case walk.UploadFile, walk.UpdateFile:
+		absolute := filepath.ToSlash(filepath.Clean(filepath.Join(d.publicPath, operation.Path)))
+
+		handler, err := os.Open(absolute)
+		if err != nil {
+			return err
+		}
+		defer func(handler *os.File) {
+			_ = handler.Close()
+		}(handler)
+
+		if err = d.client.UploadNewFile(operation.Path, handler); err != nil {
+			return err
+		}
+
+		break
+	case walk.RemoveFile:
+		if err := d.client.RemoveFile(operation.Path); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// removeEmptyDirs removes remote directories, from which all files were removed and which don't hold any local file.
+func (d *Deployment) removeEmptyDirs(removed []string, local walk.FileMap) error {
+	// Collect directories still in use by the local files.
+	used := make(map[string]struct{})
+	for file := range local {
+		for dir := path.Dir(file); dir != "."; dir = path.Dir(dir) {
+			used[dir] = struct{}{}
+		}
+	}
+
+	// Find the topmost unused directories, so we send just one DELETE per removed tree.
+	toRemove := make(map[string]struct{})
+	for _, file := range removed {
+		var topmost string
+		for dir := path.Dir(file); dir != "."; dir = path.Dir(dir) {
+			if _, ok := used[dir]; ok {
+				break
+			}
+			topmost = dir
+		}
+
+		if topmost != "" {
+			toRemove[topmost] = struct{}{}
+		}
+	}
+
+	for dir := range toRemove {
+		if err := d.client.RemoveDir(dir); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// remoteFiles returns a map of remote files indexed by their relative path.
+func (d *Deployment) remoteFiles() (walk.FileMap, error) {
+	files, errors := d.client.RemoteFiles()
+	if errors != nil {
+		var errorsString []string
+		for _, err := range errors {
+			errorsString = append(errorsString, err.Error())
+		}
+
+		return nil, fmt.Errorf("errors getting remote files: %s", strings.Join(errorsString, "\n"))
+	}
+
+	return files, nil
+}
+
+// retrieveFiles walks the public directory and returns a channel of files to be uploaded.
+func (d *Deployment) retrieveFiles() (walk.FileWalk, error) {
+	walker := make(walk.FileWalk)
+
+	// Gather the files to upload by walking the path recursively.
+	go func() {
+		defer close(walker)
+		if err := filepath.Walk(d.publicPath, walker.Walk); err != nil {
+			panic(err)
+		}
+	}()
+
+	return walker, nil
+}
+
+// getFileMap returns locally retrieved files in form of a fileMap indexed by their relative path.
+func (d *Deployment) getFileMap(fileWalk walk.FileWalk) (walk.FileMap, error) {
+	fileMap := make(walk.FileMap)
+
+	for file := range fileWalk {
+		fileInfo, err := os.Stat(file)
+		if err != nil {
+			return nil, err
+		}
+
+		relPath, _ := filepath.Rel(d.publicPath, file)
+		relPath = filepath.ToSlash(relPath)
+
+		fileMap[relPath] = fileInfo
+	}
+
+	return fileMap, nil
+}
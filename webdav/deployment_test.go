@@ -0,0 +1,215 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package webdav
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"github.com/kovansky/midas"
+	"github.com/kovansky/midas/testing_utils"
+	xwebdav "golang.org/x/net/webdav"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testUser     = "midas"
+	testPassword = "secret"
+	testRealm    = "midas-test"
+	testNonce    = "dcd98b7102dd2f0e8b11d0f600bfb0c093"
+)
+
+func TestDeployment_Deploy(t *testing.T) {
+	tests := []struct {
+		name    string
+		method  string
+		handler func(next http.Handler) http.Handler
+	}{
+		{"Basic", "basic", basicAuth},
+		{"Digest", "digest", digestAuth},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			remoteDir := t.TempDir()
+			server := httptest.NewServer(tt.handler(&xwebdav.Handler{
+				Prefix:     "/dav",
+				FileSystem: xwebdav.Dir(remoteDir),
+				LockSystem: xwebdav.NewMemLS(),
+			}))
+			defer server.Close()
+
+			site := midas.Site{RootDir: t.TempDir()}
+			settings := midas.DeploymentSettings{
+				Enabled: true,
+				Target:  "webdav",
+				WebDAV: midas.WebDAVDeploymentSettings{
+					Url:      server.URL + "/dav",
+					User:     testUser,
+					Method:   tt.method,
+					Password: testPassword,
+					Path:     "mysite",
+				},
+			}
+			publicPath := site.PublicPath(false)
+			remotePath := filepath.Join(remoteDir, "mysite")
+
+			writeFiles(t, publicPath, map[string]string{
+				"index.html":             "home",
+				"css/style.css":          "body {}",
+				"posts/first/index.html": "first",
+			})
+
+			deployment, err := New(site, settings, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			t.Run("Initial", func(t *testing.T) {
+				if err := deployment.Deploy(); err != nil {
+					t.Fatal(err)
+				}
+
+				testing_utils.AssertTable(t, map[string][]interface{}{
+					"index.html":             {readFile(t, remotePath, "index.html"), "home"},
+					"css/style.css":          {readFile(t, remotePath, "css/style.css"), "body {}"},
+					"posts/first/index.html": {readFile(t, remotePath, "posts/first/index.html"), "first"},
+				})
+			})
+
+			t.Run("Sync", func(t *testing.T) {
+				if err := os.RemoveAll(filepath.Join(publicPath, "posts", "first")); err != nil {
+					t.Fatal(err)
+				}
+				writeFiles(t, publicPath, map[string]string{
+					"index.html":              "new home",
+					"posts/second/index.html": "second",
+				})
+
+				// Remote modification times are set on upload, so make sure the local change is newer.
+				future := time.Now().Add(time.Hour)
+				if err := os.Chtimes(filepath.Join(publicPath, "index.html"), future, future); err != nil {
+					t.Fatal(err)
+				}
+
+				plan, err := deployment.Plan()
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				testing_utils.AssertTable(t, map[string][]interface{}{
+					"Plan upload":        {strings.Join(plan.Upload, ","), "/dav/mysite/posts/second/index.html"},
+					"Plan update":        {strings.Join(plan.Update, ","), "/dav/mysite/index.html"},
+					"Plan delete":        {strings.Join(plan.Delete, ","), "/dav/mysite/posts/first/index.html"},
+					"Remote not changed": {readFile(t, remotePath, "index.html"), "home"},
+				})
+
+				if err := deployment.Deploy(); err != nil {
+					t.Fatal(err)
+				}
+
+				_, firstErr := os.Stat(filepath.Join(remotePath, "posts", "first"))
+
+				testing_utils.AssertTable(t, map[string][]interface{}{
+					"index.html":              {readFile(t, remotePath, "index.html"), "new home"},
+					"posts/second/index.html": {readFile(t, remotePath, "posts/second/index.html"), "second"},
+					"posts/first removed":     {os.IsNotExist(firstErr), true},
+				})
+			})
+		})
+	}
+}
+
+func TestParseDigestChallenge(t *testing.T) {
+	challenge, err := parseDigestChallenge(`Digest realm="test, realm", qop="auth,auth-int", nonce="abc", opaque="xyz"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testing_utils.AssertTable(t, map[string][]interface{}{
+		"Realm":     {challenge.realm, "test, realm"},
+		"Nonce":     {challenge.nonce, "abc"},
+		"Opaque":    {challenge.opaque, "xyz"},
+		"Qop":       {challenge.qop, "auth"},
+		"Algorithm": {challenge.algorithm, "MD5"},
+	})
+
+	if _, err = parseDigestChallenge(`Basic realm="test"`); err == nil {
+		t.Fatal("expected error for non-digest challenge")
+	}
+}
+
+// basicAuth is a middleware requiring HTTP Basic authentication.
+func basicAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, password, ok := r.BasicAuth(); ok && user == testUser && password == testPassword {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Basic realm="%s"`, testRealm))
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+}
+
+// digestAuth is a middleware requiring HTTP Digest authentication (MD5 with qop=auth).
+func digestAuth(next http.Handler) http.Handler {
+	md5hex := func(values ...string) string {
+		sum := md5.Sum([]byte(strings.Join(values, ":")))
+		return hex.EncodeToString(sum[:])
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Digest ") {
+			params := parseDigestParams(strings.TrimPrefix(auth, "Digest "))
+
+			ha1 := md5hex(testUser, testRealm, testPassword)
+			ha2 := md5hex(r.Method, params["uri"])
+			expected := md5hex(ha1, testNonce, params["nc"], params["cnonce"], params["qop"], ha2)
+
+			if params["username"] == testUser && params["uri"] == r.URL.RequestURI() && params["response"] == expected {
+				next.ServeHTTP(w, r)
+				return
+			}
+		}
+
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Digest realm="%s", qop="auth", nonce="%s", algorithm=MD5`, testRealm, testNonce))
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+}
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+
+		if err := os.MkdirAll(filepath.Dir(path), 0775); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0664); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func readFile(t *testing.T, root, name string) string {
+	t.Helper()
+
+	content, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(name)))
+	if err != nil {
+		return ""
+	}
+
+	return string(content)
+}
@@ -0,0 +1,157 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package webdav
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"strings"
+)
+
+// digestChallenge holds the parameters of the HTTP Digest authentication challenge (RFC 7616) sent by the server.
+type digestChallenge struct {
+	realm     string
+	nonce     string
+	opaque    string
+	algorithm string
+	qop       string
+}
+
+// parseDigestChallenge parses the value of WWW-Authenticate header containing the Digest challenge.
+func parseDigestChallenge(header string) (*digestChallenge, error) {
+	if !strings.HasPrefix(strings.ToLower(header), "digest ") {
+		return nil, fmt.Errorf("not a digest challenge: %s", header)
+	}
+
+	params := parseDigestParams(header[len("digest "):])
+
+	challenge := &digestChallenge{
+		realm:     params["realm"],
+		nonce:     params["nonce"],
+		opaque:    params["opaque"],
+		algorithm: params["algorithm"],
+	}
+
+	if challenge.nonce == "" {
+		return nil, fmt.Errorf("digest challenge has no nonce")
+	}
+
+	if challenge.algorithm == "" {
+		challenge.algorithm = "MD5"
+	}
+
+	// We only support "auth" quality of protection. If the server doesn't offer it, legacy (RFC 2069) mode is used.
+	for _, qop := range strings.Split(params["qop"], ",") {
+		if strings.TrimSpace(qop) == "auth" {
+			challenge.qop = "auth"
+		}
+	}
+
+	return challenge, nil
+}
+
+// authorization returns the value of Authorization header for the given request.
+func (d *digestChallenge) authorization(user, password, method, uri string, nonceCount int) (string, error) {
+	var hashFn func() hash.Hash
+
+	switch strings.TrimSuffix(strings.ToUpper(d.algorithm), "-SESS") {
+	case "MD5":
+		hashFn = md5.New
+	case "SHA-256":
+		hashFn = sha256.New
+	default:
+		return "", fmt.Errorf("unsupported digest algorithm %s", d.algorithm)
+	}
+
+	digest := func(values ...string) string {
+		h := hashFn()
+		h.Write([]byte(strings.Join(values, ":")))
+		return hex.EncodeToString(h.Sum(nil))
+	}
+
+	cnonce, err := randomHex(16)
+	if err != nil {
+		return "", err
+	}
+	nc := fmt.Sprintf("%08x", nonceCount)
+
+	ha1 := digest(user, d.realm, password)
+	if strings.HasSuffix(strings.ToUpper(d.algorithm), "-SESS") {
+		ha1 = digest(ha1, d.nonce, cnonce)
+	}
+	ha2 := digest(method, uri)
+
+	var response string
+	if d.qop == "" {
+		response = digest(ha1, d.nonce, ha2)
+	} else {
+		response = digest(ha1, d.nonce, nc, cnonce, d.qop, ha2)
+	}
+
+	header := fmt.Sprintf(`Digest username="%s", realm="%s", nonce="%s", uri="%s", algorithm=%s, response="%s"`,
+		user, d.realm, d.nonce, uri, d.algorithm, response)
+
+	if d.qop != "" {
+		header += fmt.Sprintf(`, qop=%s, nc=%s, cnonce="%s"`, d.qop, nc, cnonce)
+	}
+	if d.opaque != "" {
+		header += fmt.Sprintf(`, opaque="%s"`, d.opaque)
+	}
+
+	return header, nil
+}
+
+// parseDigestParams splits comma separated key=value (or key="value") list into a map.
+func parseDigestParams(s string) map[string]string {
+	params := make(map[string]string)
+
+	for len(s) > 0 {
+		s = strings.TrimLeft(s, " ,")
+
+		eq := strings.IndexByte(s, '=')
+		if eq < 0 {
+			break
+		}
+
+		key := strings.ToLower(strings.TrimSpace(s[:eq]))
+		s = s[eq+1:]
+
+		var value string
+		if strings.HasPrefix(s, `"`) {
+			end := strings.IndexByte(s[1:], '"')
+			if end < 0 {
+				value, s = s[1:], ""
+			} else {
+				value, s = s[1:end+1], s[end+2:]
+			}
+		} else {
+			end := strings.IndexByte(s, ',')
+			if end < 0 {
+				value, s = s, ""
+			} else {
+				value, s = s[:end], s[end:]
+			}
+		}
+
+		params[key] = strings.TrimSpace(value)
+	}
+
+	return params
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,142 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package zola
+
+import (
+	"context"
+	"github.com/kovansky/midas"
+	"github.com/kovansky/midas/concurrent"
+	"github.com/kovansky/midas/content"
+	"github.com/rs/zerolog"
+	"os/exec"
+	"path/filepath"
+)
+
+var _ midas.SiteService = (*SiteService)(nil)
+
+var defaultCommand = []string{"zola"}
+
+type SiteService struct {
+	content.Service
+}
+
+func NewSiteService(config midas.Site) (midas.SiteService, error) {
+	service, err := content.NewService(config, Layout{})
+	if err != nil {
+		return nil, err
+	}
+
+	return SiteService{service}, nil
+}
+
+// BuildSite builds the site with Zola, followed by the drafts build if enabled. Zola has no build cache, so useCache
+// is ignored.
+func (s SiteService) BuildSite(_ bool, _ zerolog.Logger) error {
+	err := concurrent.Run(s.Site, "zola", func(ctx context.Context) *exec.Cmd {
+		return s.command(ctx, false)
+	})
+	if err != nil {
+		return err
+	}
+
+	if s.Site.BuildDrafts {
+		return s.BuildDrafts()
+	}
+
+	return nil
+}
+
+func (s SiteService) BuildDrafts() error {
+	out, err := s.command(context.Background(), true).CombinedOutput()
+	if err != nil {
+		return midas.Errorf(midas.ErrDraftsBuild, "zola draft build errored: %s\ncommand output: %s", err, out)
+	}
+
+	return nil
+}
+
+// command returns the Zola build command, writing to the output dir of the build (replacing the existing one). The
+// drafts build includes the drafts, with the drafts URL as the base URL if set.
+func (s SiteService) command(ctx context.Context, isDraft bool) *exec.Cmd {
+	command := s.Site.Zola.Command
+	if len(command) == 0 {
+		command = defaultCommand
+	}
+
+	output := s.Site.BuildOutput(isDraft)
+
+	arg := append(append([]string{}, command[1:]...), "build", "--output-dir", output.Dir, "--force")
+
+	if output.Drafts {
+		arg = append(arg, "--drafts")
+
+		if output.BaseUrl != "" {
+			arg = append(arg, "--base-url", output.BaseUrl)
+		}
+	}
+
+	cmd := exec.CommandContext(ctx, command[0], arg...)
+	cmd.Dir = s.Site.RootDir
+
+	return cmd
+}
+
+// Layout writes the entries of the collection types as markdown pages (i.e. in the content/blog section), with TOML
+// front matter by default, and the single types as JSON data files (to be loaded with load_data).
+type Layout struct{}
+
+func (l Layout) EntryPath(modelName string, model *midas.ModelSettings, payload midas.Payload) (string, string, string, error) {
+	if model.Output != "" && model.Output != midas.OutputMarkdown {
+		return "", "", "", midas.Errorf(midas.ErrSiteConfig, "output %s of model %s is not supported", model.Output, modelName)
+	}
+	if model.FrontMatter == midas.FrontMatterJSON {
+		return "", "", "", midas.Errorf(midas.ErrSiteConfig, "front matter format %s is not supported", model.FrontMatter)
+	}
+
+	filename, err := content.EntryFilename(modelName, model, payload)
+
+	return model.OutputDir, filename, ".md", err
+}
+
+// RenderEntry renders the entry as markdown. Zola accepts only its own front matter variables, so the entry fields are
+// placed in the extra table, and the title, date, updated and draft variables are filled from the entry.
+func (l Layout) RenderEntry(model *midas.ModelSettings, payload midas.Payload) ([]byte, error) {
+	entry := payload.Entry()
+
+	frontMatter := make(map[string]interface{})
+	predefined := map[string]interface{}{
+		"title":   content.Title(model, entry),
+		"date":    content.Date(entry),
+		"updated": entry["updatedAt"],
+		"draft":   payload.Metadata()["draft"],
+	}
+
+	for key, value := range predefined {
+		if value = content.FrontMatterValue(value); value != nil {
+			frontMatter[key] = value
+		}
+	}
+
+	if extra := content.FrontMatter(model, entry); len(extra) > 0 {
+		frontMatter["extra"] = extra
+	}
+
+	tomlModel := *model
+	if tomlModel.FrontMatter == "" {
+		tomlModel.FrontMatter = midas.FrontMatterTOML
+	}
+
+	return content.Markdown(&tomlModel, frontMatter, entry)
+}
+
+func (l Layout) SinglePath(modelName string, model *midas.ModelSettings, _ string) string {
+	return filepath.Join(model.OutputDir, modelName+".json")
+}
+
+func (l Layout) RenderSingle(_ *midas.ModelSettings, payload midas.Payload) ([]byte, error) {
+	return content.RenderJSON(payload)
+}
@@ -0,0 +1,129 @@
+/*
+ * Copyright (c) 2026.
+ *
+ * Originally created by F4 Developer (Stanisław Kowański). Released under GNU GPLv3 (see LICENSE)
+ */
+
+package zola
+
+import (
+	"fmt"
+	"github.com/kovansky/midas"
+	"github.com/kovansky/midas/concurrent"
+	"github.com/kovansky/midas/content"
+	"github.com/kovansky/midas/mock"
+	"github.com/kovansky/midas/testing_utils"
+	"github.com/rs/zerolog"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLayout(t *testing.T) {
+	layout := Layout{}
+	draft := mock.NewPayload("post", map[string]interface{}{
+		"id": 1, "Title": "First", "Content": "Hello", "createdAt": "2022-01-01T10:10:10.000Z",
+	}, "draft", true)
+	published := mock.NewPayload("page", map[string]interface{}{
+		"id": 2, "Title": "About", "Content": "Hello", "publishedAt": "2022-01-03T10:10:10.000Z",
+	}, "draft", false)
+
+	t.Run("TOML", func(t *testing.T) {
+		model := &midas.ModelSettings{OutputDir: "content/blog", Filename: `{{ date "2006-01-02" .Entry.createdAt }}-{{ slug .Title }}`}
+
+		dir, filename, extension, err := layout.EntryPath("post", model, draft)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rendered, err := layout.RenderEntry(model, draft)
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Path":  {filepath.Join(dir, filename+extension), filepath.Join("content", "blog", "2022-01-01-first.md")},
+			"Error": {err, nil},
+			"Content": {string(rendered), `+++
+date = 2022-01-01T10:10:10Z
+draft = true
+title = "First"
+
+[extra]
+Title = "First"
+createdAt = 2022-01-01T10:10:10Z
+id = 1
++++
+
+Hello
+`},
+		})
+	})
+
+	t.Run("YAML", func(t *testing.T) {
+		rendered, err := layout.RenderEntry(&midas.ModelSettings{OutputDir: "content/pages", FrontMatter: midas.FrontMatterYAML}, published)
+
+		testing_utils.AssertTable(t, map[string][]interface{}{
+			"Error": {err, nil},
+			"Content": {string(rendered), `---
+date: 2022-01-03T10:10:10Z
+draft: false
+extra:
+    Title: About
+    id: 2
+    publishedAt: 2022-01-03T10:10:10Z
+title: About
+---
+
+Hello
+`},
+		})
+	})
+
+	t.Run("Single", func(t *testing.T) {
+		path := layout.SinglePath("homepage", &midas.ModelSettings{OutputDir: "data"}, "")
+
+		testing_utils.AssertEquals(t, path, filepath.Join("data", "homepage.json"), "Path")
+	})
+
+	t.Run("UnsupportedFrontMatter", func(t *testing.T) {
+		_, _, _, err := layout.EntryPath("note", &midas.ModelSettings{OutputDir: "content/notes", FrontMatter: midas.FrontMatterJSON}, draft)
+
+		testing_utils.AssertEquals(t, midas.ErrorCode(err), midas.ErrSiteConfig, "Error code")
+	})
+}
+
+func TestSiteService_BuildSite(t *testing.T) {
+	midas.Concurrents = concurrent.NewList()
+
+	root := t.TempDir()
+	log := filepath.Join(root, "zola.log")
+
+	// The stub records the arguments of each run instead of building the site.
+	stub := filepath.Join(root, "zola.sh")
+	script := "#!/bin/sh\necho \"$@\" >> " + log + "\n"
+	if err := os.WriteFile(stub, []byte(script), 0775); err != nil {
+		t.Fatal(err)
+	}
+
+	site := SiteService{content.Service{Site: midas.Site{
+		SiteName:       "zola",
+		RootDir:        root,
+		BuildDrafts:    true,
+		DraftsUrl:      "http://preview.zola.local",
+		OutputSettings: midas.OutputSettings{Build: "dist"},
+		Zola:           midas.ZolaSettings{Command: []string{stub}},
+	}}}
+
+	if err := site.BuildSite(true, zerolog.Nop()); err != nil {
+		t.Fatal(err)
+	}
+
+	runs, err := os.ReadFile(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := fmt.Sprintf("build --output-dir %s --force\n"+
+		"build --output-dir %s --force --drafts --base-url http://preview.zola.local\n",
+		filepath.Join(root, "dist"), filepath.Join(root, "publicDrafts"))
+
+	testing_utils.AssertEquals(t, string(runs), expected, "Runs")
+}